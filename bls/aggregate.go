@@ -0,0 +1,83 @@
+package bls
+
+import (
+	"errors"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+var (
+	ErrEmptyAggregate   = errors.New("nothing to aggregate")
+	ErrLengthMismatch   = errors.New("number of public keys and messages differ")
+	ErrDuplicateMessage = errors.New("duplicate message in aggregate")
+)
+
+// AggregateSignatures combines sigs into a single signature.
+func AggregateSignatures(sigs []*Signature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, ErrEmptyAggregate
+	}
+	aggJac := new(bls12381.G2Jac).FromAffine(sigs[0])
+	for _, sig := range sigs[1:] {
+		aggJac.AddMixed(sig)
+	}
+	return new(Signature).FromJacobian(aggJac), nil
+}
+
+// AggregatePublicKeys combines pks into a single public key. As in the
+// consensus specs, none of the keys may be the point at infinity.
+func AggregatePublicKeys(pks []*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, ErrEmptyAggregate
+	}
+	aggJac := new(bls12381.G1Jac)
+	for _, pk := range pks {
+		if pk.IsInfinity() {
+			return nil, ErrPubkeyIsInfinity
+		}
+		aggJac.AddMixed(pk)
+	}
+	return new(PublicKey).FromJacobian(aggJac), nil
+}
+
+// FastAggregateVerify verifies an aggregate signature of many public keys
+// over the same message.
+func FastAggregateVerify(sig *Signature, pks []*PublicKey, msg []byte) (bool, error) {
+	aggPk, err := AggregatePublicKeys(pks)
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(sig, aggPk, msg)
+}
+
+// AggregateVerify verifies an aggregate signature where pks[i] signed
+// msgs[i]. The messages must be distinct.
+func AggregateVerify(sig *Signature, pks []*PublicKey, msgs [][]byte) (bool, error) {
+	return coreAggregateVerify(sig, pks, msgs, domain, true)
+}
+
+// coreAggregateVerify rejects repeated messages if distinct is set, which
+// every scheme but message augmentation requires.
+func coreAggregateVerify(sig *Signature, pks []*PublicKey, msgs [][]byte, dst []byte, distinct bool) (bool, error) {
+	if len(pks) == 0 {
+		return false, ErrEmptyAggregate
+	}
+	if len(pks) != len(msgs) {
+		return false, ErrLengthMismatch
+	}
+
+	seen := make(map[string]struct{}, len(msgs))
+	for i, pk := range pks {
+		if pk.IsInfinity() {
+			return false, ErrPubkeyIsInfinity
+		}
+		if !distinct {
+			continue
+		}
+		if _, ok := seen[string(msgs[i])]; ok {
+			return false, ErrDuplicateMessage
+		}
+		seen[string(msgs[i])] = struct{}{}
+	}
+	return backend.AggregateVerify(sig, pks, msgs, dst)
+}
@@ -0,0 +1,85 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func genKeypairs(t *testing.T, n int) ([]*SecretKey, []*PublicKey) {
+	t.Helper()
+	sks := make([]*SecretKey, n)
+	pks := make([]*PublicKey, n)
+	for i := range n {
+		sk, pk, err := GenerateNewKeypair()
+		require.NoError(t, err)
+		sks[i], pks[i] = sk, pk
+	}
+	return sks, pks
+}
+
+func TestAggregateSignaturesEmpty(t *testing.T) {
+	_, err := AggregateSignatures(nil)
+	require.ErrorIs(t, err, ErrEmptyAggregate)
+	_, err = AggregatePublicKeys(nil)
+	require.ErrorIs(t, err, ErrEmptyAggregate)
+}
+
+func TestFastAggregateVerify(t *testing.T) {
+	sks, pks := genKeypairs(t, 3)
+	msg := []byte("registration")
+
+	sigs := make([]*Signature, len(sks))
+	for i, sk := range sks {
+		sigs[i] = Sign(sk, msg)
+	}
+	aggSig, err := AggregateSignatures(sigs)
+	require.NoError(t, err)
+
+	ok, err := FastAggregateVerify(aggSig, pks, msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = FastAggregateVerify(aggSig, pks, []byte("other"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = FastAggregateVerify(aggSig, pks[:2], msg)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = FastAggregateVerify(aggSig, nil, msg)
+	require.ErrorIs(t, err, ErrEmptyAggregate)
+
+	_, err = FastAggregateVerify(aggSig, []*PublicKey{pks[0], new(PublicKey)}, msg)
+	require.ErrorIs(t, err, ErrPubkeyIsInfinity)
+}
+
+func TestAggregateVerify(t *testing.T) {
+	sks, pks := genKeypairs(t, 3)
+	msgs := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+
+	sigs := make([]*Signature, len(sks))
+	for i, sk := range sks {
+		sigs[i] = Sign(sk, msgs[i])
+	}
+	aggSig, err := AggregateSignatures(sigs)
+	require.NoError(t, err)
+
+	ok, err := AggregateVerify(aggSig, pks, msgs)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = AggregateVerify(aggSig, []*PublicKey{pks[1], pks[0], pks[2]}, msgs)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = AggregateVerify(aggSig, pks, msgs[:2])
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, err = AggregateVerify(aggSig, nil, nil)
+	require.ErrorIs(t, err, ErrEmptyAggregate)
+
+	_, err = AggregateVerify(aggSig, pks, [][]byte{msgs[0], msgs[1], msgs[0]})
+	require.ErrorIs(t, err, ErrDuplicateMessage)
+}
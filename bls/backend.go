@@ -0,0 +1,41 @@
+package bls
+
+// Backend is a BLS12-381 implementation that the signing and verification
+// functions of this package delegate to. All backends operate on the same
+// PublicKey, SecretKey and Signature types and must produce identical
+// results; callers choose one for speed, not for semantics.
+//
+// Inputs are validated by the package before they reach the backend:
+// public keys are never the point at infinity, slices have matching
+// non-zero lengths and aggregate messages are distinct, except under
+// CiphersuiteAug, where they may repeat.
+type Backend interface {
+	// Name identifies the backend, e.g. "gnark" or "blst".
+	Name() string
+	// Sign signs msg with sk under the domain separation tag dst. It
+	// returns ErrSecretKeyIsZero for the zero key, e.g. one zeroized
+	// concurrently.
+	Sign(sk *SecretKey, msg, dst []byte) (*Signature, error)
+	// Verify checks that sig is a signature of msg by pk.
+	Verify(sig *Signature, pk *PublicKey, msg, dst []byte) (bool, error)
+	// AggregateVerify checks that sig aggregates signatures of msgs[i] by pks[i].
+	AggregateVerify(sig *Signature, pks []*PublicKey, msgs [][]byte, dst []byte) (bool, error)
+	// VerifyBatch checks all sets at once using random linear combinations.
+	VerifyBatch(sets []*SignatureSet, dst []byte) (bool, error)
+}
+
+// backend is the Backend in use. It defaults to gnark and is replaced by
+// blst when the package is built with the "blst" tag.
+var backend Backend = gnarkBackend{}
+
+// SetBackend makes b the Backend used by the package. It is not safe to
+// call concurrently with other functions of the package and is meant to be
+// called during initialization.
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// CurrentBackend returns the Backend in use.
+func CurrentBackend() Backend {
+	return backend
+}
@@ -0,0 +1,128 @@
+//go:build blst && cgo
+
+package bls
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var errInvalidBlstPoint = errors.New("point could not be converted to blst")
+
+// blstBackend is the cgo Backend built on supranational/blst. Points are
+// handed over in uncompressed form, which avoids square roots and subgroup
+// checks on both sides; callers are expected to have validated their points
+// when decoding them, as with the gnark backend.
+type blstBackend struct{}
+
+func init() {
+	backend = blstBackend{}
+}
+
+// BlstBackend returns the cgo Backend built on supranational/blst. It is
+// only available when building with the "blst" tag, in which case it is
+// also the default.
+func BlstBackend() Backend {
+	return blstBackend{}
+}
+
+func (blstBackend) Name() string {
+	return "blst"
+}
+
+func (blstBackend) Sign(sk *SecretKey, msg, dst []byte) (*Signature, error) {
+	var sig *blst.P2Affine
+	err := ErrSecretKeyIsZero
+	sk.withScalar(func(e *fr.Element) {
+		if e.IsZero() {
+			return
+		}
+		skBytes := e.Bytes()
+		blstSk := new(blst.SecretKey).Deserialize(skBytes[:])
+		clear(skBytes[:])
+		if blstSk == nil {
+			err = ErrInvalidSecretKeyLength
+			return
+		}
+		defer blstSk.Zeroize()
+		sig = new(blst.P2Affine).Sign(blstSk, msg, dst)
+		err = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromBlstP2(sig)
+}
+
+func (blstBackend) Verify(sig *Signature, pk *PublicKey, msg, dst []byte) (bool, error) {
+	blstSig, blstPk := toBlstP2(sig), toBlstP1(pk)
+	if blstSig == nil || blstPk == nil {
+		return false, nil
+	}
+	return blstSig.Verify(false, blstPk, false, msg, dst), nil
+}
+
+func (blstBackend) AggregateVerify(sig *Signature, pks []*PublicKey, msgs [][]byte, dst []byte) (bool, error) {
+	blstSig := toBlstP2(sig)
+	if blstSig == nil {
+		return false, nil
+	}
+	blstPks := make([]*blst.P1Affine, len(pks))
+	blstMsgs := make([]blst.Message, len(msgs))
+	for i, pk := range pks {
+		if blstPks[i] = toBlstP1(pk); blstPks[i] == nil {
+			return false, nil
+		}
+		blstMsgs[i] = msgs[i]
+	}
+	return blstSig.AggregateVerify(false, blstPks, false, blstMsgs, dst), nil
+}
+
+func (blstBackend) VerifyBatch(sets []*SignatureSet, dst []byte) (bool, error) {
+	blstSigs := make([]*blst.P2Affine, len(sets))
+	blstPks := make([]*blst.P1Affine, len(sets))
+	blstMsgs := make([]blst.Message, len(sets))
+	for i, set := range sets {
+		blstSigs[i], blstPks[i] = toBlstP2(set.Signature), toBlstP1(set.PublicKey)
+		if blstSigs[i] == nil || blstPks[i] == nil {
+			return false, nil
+		}
+		blstMsgs[i] = set.Message
+	}
+
+	randFn := func(s *blst.Scalar) {
+		// crypto/rand.Read does not fail since Go 1.24.
+		r, _ := randomScalar()
+		var b [32]byte
+		binary.LittleEndian.PutUint64(b[:], r)
+		s.FromLEndian(b[:])
+	}
+	return new(blst.P2Affine).MultipleAggregateVerify(blstSigs, false, blstPks, false, blstMsgs, dst, randFn, 64), nil
+}
+
+func toBlstP1(p *bls12381.G1Affine) *blst.P1Affine {
+	raw := p.RawBytes()
+	return new(blst.P1Affine).Deserialize(raw[:])
+}
+
+func toBlstP2(p *bls12381.G2Affine) *blst.P2Affine {
+	raw := p.RawBytes()
+	return new(blst.P2Affine).Deserialize(raw[:])
+}
+
+func fromBlstP2(p *blst.P2Affine) (*bls12381.G2Affine, error) {
+	if p == nil {
+		return nil, errInvalidBlstPoint
+	}
+	sig := new(bls12381.G2Affine)
+	dec := bls12381.NewDecoder(bytes.NewReader(p.Serialize()), bls12381.NoSubgroupChecks())
+	if err := dec.Decode(sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
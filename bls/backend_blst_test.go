@@ -0,0 +1,187 @@
+//go:build blst && cgo
+
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// requireBackendsAgree runs f against the gnark and blst backends and
+// requires identical results.
+func requireBackendsAgree[T any](t *testing.T, f func(b Backend) (T, error)) T {
+	t.Helper()
+	gnarkRes, gnarkErr := f(GnarkBackend())
+	blstRes, blstErr := f(BlstBackend())
+	require.Equal(t, gnarkErr == nil, blstErr == nil, "gnark: %v, blst: %v", gnarkErr, blstErr)
+	require.Equal(t, gnarkRes, blstRes)
+	return gnarkRes
+}
+
+func TestBlstIsDefaultBackend(t *testing.T) {
+	require.Equal(t, "blst", CurrentBackend().Name())
+}
+
+func TestBackendsSignVerify(t *testing.T) {
+	sks, pks := genKeypairs(t, 8)
+	for i, sk := range sks {
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sigBytes := requireBackendsAgree(t, func(b Backend) ([]byte, error) {
+			sig, err := b.Sign(sk, msg, domain)
+			if err != nil {
+				return nil, err
+			}
+			return SignatureToBytes(sig), nil
+		})
+		sig, err := SignatureFromBytes(sigBytes)
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			pk       *PublicKey
+			msg      []byte
+			sig      *Signature
+			expected bool
+		}{
+			{pks[i], msg, sig, true},
+			{pks[i], []byte("other"), sig, false},
+			{pks[(i+1)%len(pks)], msg, sig, false},
+			{pks[i], msg, new(Signature), false},
+		} {
+			ok := requireBackendsAgree(t, func(b Backend) (bool, error) {
+				return b.Verify(tc.sig, tc.pk, tc.msg, domain)
+			})
+			require.Equal(t, tc.expected, ok)
+		}
+	}
+
+	ok := requireBackendsAgree(t, func(b Backend) (bool, error) {
+		return b.Verify(new(Signature), new(PublicKey), []byte("message"), domain)
+	})
+	require.False(t, ok)
+
+	requireBackendsAgree(t, func(b Backend) ([]byte, error) {
+		sig, err := b.Sign(new(SecretKey), []byte("message"), domain)
+		if err != nil {
+			return nil, err
+		}
+		return SignatureToBytes(sig), nil
+	})
+}
+
+func TestBackendsAggregateVerify(t *testing.T) {
+	sks, pks := genKeypairs(t, 4)
+	msgs := make([][]byte, len(sks))
+	sigs := make([]*Signature, len(sks))
+	for i, sk := range sks {
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i] = Sign(sk, msgs[i])
+	}
+	aggSig, err := AggregateSignatures(sigs)
+	require.NoError(t, err)
+
+	ok := requireBackendsAgree(t, func(b Backend) (bool, error) {
+		return b.AggregateVerify(aggSig, pks, msgs, domain)
+	})
+	require.True(t, ok)
+
+	ok = requireBackendsAgree(t, func(b Backend) (bool, error) {
+		return b.AggregateVerify(sigs[0], pks, msgs, domain)
+	})
+	require.False(t, ok)
+}
+
+func TestBackendsVerifyBatch(t *testing.T) {
+	sets := genSignatureSets(t, 16)
+	ok := requireBackendsAgree(t, func(b Backend) (bool, error) {
+		return b.VerifyBatch(sets, domain)
+	})
+	require.True(t, ok)
+
+	sets[5].Signature = sets[4].Signature
+	ok = requireBackendsAgree(t, func(b Backend) (bool, error) {
+		return b.VerifyBatch(sets, domain)
+	})
+	require.False(t, ok)
+}
+
+func TestKeyGenMatchesBlst(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		ikm := make([]byte, MinSeedLength+i)
+		_, _ = rand.Read(ikm)
+		keyInfo := []byte(fmt.Sprintf("key info %d", i))
+
+		sk, err := KeyGen(ikm, nil, keyInfo)
+		require.NoError(t, err)
+		require.Equal(t, blst.KeyGen(ikm, keyInfo).Serialize(), SecretKeyToBytes(sk))
+
+		salt := []byte(fmt.Sprintf("salt %d", i))
+		sk, err = KeyGen(ikm, salt, keyInfo)
+		require.NoError(t, err)
+		require.Equal(t, blst.KeyGenV5(ikm, salt, keyInfo).Serialize(), SecretKeyToBytes(sk))
+	}
+}
+
+func TestCiphersuitesMatchBlst(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	blstSk := new(blst.SecretKey).Deserialize(SecretKeyToBytes(sk))
+	msg := []byte("message")
+
+	for _, c := range ciphersuites {
+		dst, err := c.DST()
+		require.NoError(t, err)
+		var aug []byte
+		if c == CiphersuiteAug {
+			aug = PublicKeyToBytes(pk)
+		}
+		expected := new(blst.P2Affine).Sign(blstSk, msg, dst, aug)
+
+		sig, err := c.Sign(sk, msg)
+		require.NoError(t, err)
+		require.Equal(t, expected.Compress(), SignatureToBytes(sig), c)
+	}
+}
+
+func TestAugAggregateVerifyRepeatedPairMatchesBlst(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	blstSk := new(blst.SecretKey).Deserialize(SecretKeyToBytes(sk))
+	blstPk := new(blst.P1Affine).From(blstSk)
+	msg := []byte("message")
+	dst, err := CiphersuiteAug.DST()
+	require.NoError(t, err)
+	aug := PublicKeyToBytes(pk)
+
+	blstSig := new(blst.P2Affine).Sign(blstSk, msg, dst, aug)
+	blstAgg := new(blst.P2Aggregate)
+	require.True(t, blstAgg.Aggregate([]*blst.P2Affine{blstSig, blstSig}, false))
+	augMsg := append(aug, msg...)
+	require.True(t, blstAgg.ToAffine().AggregateVerify(false, []*blst.P1Affine{blstPk, blstPk}, false, []blst.Message{augMsg, augMsg}, dst))
+
+	aggSig, err := SignatureFromBytes(blstAgg.ToAffine().Compress())
+	require.NoError(t, err)
+	ok, err := CiphersuiteAug.AggregateVerify(aggSig, []*PublicKey{pk, pk}, [][]byte{msg, msg})
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func BenchmarkBackendVerify(b *testing.B) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(b, err)
+	msg := []byte("message")
+	sig := Sign(sk, msg)
+
+	for _, be := range []Backend{GnarkBackend(), BlstBackend()} {
+		b.Run(be.Name(), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				ok, err := be.Verify(sig, pk, msg, domain)
+				require.NoError(b, err)
+				require.True(b, ok)
+			}
+		})
+	}
+}
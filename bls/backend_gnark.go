@@ -0,0 +1,106 @@
+package bls
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// gnarkBackend is the pure Go Backend built on gnark-crypto.
+type gnarkBackend struct{}
+
+// GnarkBackend returns the pure Go Backend built on gnark-crypto.
+func GnarkBackend() Backend {
+	return gnarkBackend{}
+}
+
+func (gnarkBackend) Name() string {
+	return "gnark"
+}
+
+func (gnarkBackend) Sign(sk *SecretKey, msg, dst []byte) (*Signature, error) {
+	Q, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	QJac := new(bls12381.G2Jac).FromAffine(&Q)
+	sigJac := new(bls12381.G2Jac)
+	err = ErrSecretKeyIsZero
+	sk.withScalar(func(e *fr.Element) {
+		if e.IsZero() {
+			return
+		}
+		b := scalarBigInt(e)
+		defer clearBigInt(b)
+		sigJac.ScalarMultiplication(QJac, b)
+		err = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return new(bls12381.G2Affine).FromJacobian(sigJac), nil
+}
+
+func (gnarkBackend) Verify(sig *Signature, pk *PublicKey, msg, dst []byte) (bool, error) {
+	if pk.IsInfinity() {
+		return false, nil
+	}
+	Q, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return false, err
+	}
+	return bls12381.PairingCheck(
+		[]bls12381.G1Affine{*pk, negG1One},
+		[]bls12381.G2Affine{Q, *sig},
+	)
+}
+
+func (gnarkBackend) AggregateVerify(sig *Signature, pks []*PublicKey, msgs [][]byte, dst []byte) (bool, error) {
+	P := make([]bls12381.G1Affine, 0, len(pks)+1)
+	Q := make([]bls12381.G2Affine, 0, len(pks)+1)
+	for i, pk := range pks {
+		H, err := bls12381.HashToG2(msgs[i], dst)
+		if err != nil {
+			return false, err
+		}
+		P = append(P, *pk)
+		Q = append(Q, H)
+	}
+
+	P = append(P, negG1One)
+	Q = append(Q, *sig)
+	return bls12381.PairingCheck(P, Q)
+}
+
+// VerifyBatch checks e(g1, Σ rᵢ⋅sigᵢ) == ∏ e(rᵢ⋅pkᵢ, H(msgᵢ)).
+func (gnarkBackend) VerifyBatch(sets []*SignatureSet, dst []byte) (bool, error) {
+	P := make([]bls12381.G1Affine, 0, len(sets)+1)
+	Q := make([]bls12381.G2Affine, 0, len(sets)+1)
+	aggSigJac := new(bls12381.G2Jac)
+	r := new(big.Int)
+	for _, set := range sets {
+		H, err := bls12381.HashToG2(set.Message, dst)
+		if err != nil {
+			return false, err
+		}
+		scalar, err := randomScalar()
+		if err != nil {
+			return false, err
+		}
+		r.SetUint64(scalar)
+
+		var pk bls12381.G1Affine
+		pk.ScalarMultiplication(set.PublicKey, r)
+		var sigJac bls12381.G2Jac
+		sigJac.ScalarMultiplication(new(bls12381.G2Jac).FromAffine(set.Signature), r)
+		aggSigJac.AddAssign(&sigJac)
+
+		P = append(P, pk)
+		Q = append(Q, H)
+	}
+
+	P = append(P, negG1One)
+	Q = append(Q, *new(bls12381.G2Affine).FromJacobian(aggSigJac))
+	return bls12381.PairingCheck(P, Q)
+}
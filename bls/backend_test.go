@@ -0,0 +1,24 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBackend(t *testing.T) {
+	prev := CurrentBackend()
+	defer SetBackend(prev)
+
+	SetBackend(GnarkBackend())
+	require.Equal(t, "gnark", CurrentBackend().Name())
+	TestSignatureVerifyRealValues(t)
+}
+
+func TestVerifySignatureRejectsInfinity(t *testing.T) {
+	// e(O, H(m)) == e(g1, O) holds trivially, so an infinite public key must
+	// be rejected explicitly.
+	ok, err := VerifySignature(new(Signature), new(PublicKey), []byte("message"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
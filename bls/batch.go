@@ -0,0 +1,95 @@
+package bls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// SignatureSet is a single (public key, message, signature) triple to be
+// checked by VerifyBatch.
+type SignatureSet struct {
+	PublicKey *PublicKey
+	Message   []byte
+	Signature *Signature
+}
+
+// VerifyBatch verifies all sets with a single multi-pairing, weighting each
+// set with a random 64-bit scalar so that invalid signatures cannot cancel
+// each other out. If the batch does not verify, it is bisected to find the
+// offending sets. The returned slice holds the indices of all invalid sets
+// in ascending order and is empty if every signature is valid.
+func VerifyBatch(sets []*SignatureSet) ([]int, error) {
+	invalid := []int{}
+	valid := make([]*SignatureSet, 0, len(sets))
+	idx := make([]int, 0, len(sets))
+	for i, set := range sets {
+		if set.PublicKey.IsInfinity() {
+			invalid = append(invalid, i)
+			continue
+		}
+		valid = append(valid, set)
+		idx = append(idx, i)
+	}
+	if len(valid) == 0 {
+		return invalid, nil
+	}
+
+	bad, err := findInvalid(valid, idx)
+	if err != nil {
+		return nil, err
+	}
+	return mergeSorted(invalid, bad), nil
+}
+
+// findInvalid returns the indices of the sets that fail verification,
+// recursing into both halves whenever a sub-batch does not verify.
+func findInvalid(sets []*SignatureSet, idx []int) ([]int, error) {
+	if len(sets) == 1 {
+		ok, err := backend.Verify(sets[0].Signature, sets[0].PublicKey, sets[0].Message, domain)
+		if err != nil || ok {
+			return nil, err
+		}
+		return idx, nil
+	}
+
+	ok, err := backend.VerifyBatch(sets, domain)
+	if err != nil || ok {
+		return nil, err
+	}
+
+	mid := len(sets) / 2
+	left, err := findInvalid(sets[:mid], idx[:mid])
+	if err != nil {
+		return nil, err
+	}
+	right, err := findInvalid(sets[mid:], idx[mid:])
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+func randomScalar() (uint64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if r := binary.LittleEndian.Uint64(b[:]); r != 0 {
+			return r, nil
+		}
+	}
+}
+
+func mergeSorted(a, b []int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] < b[0] {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
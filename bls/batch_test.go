@@ -0,0 +1,75 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func genSignatureSets(t *testing.T, n int) []*SignatureSet {
+	t.Helper()
+	sks, pks := genKeypairs(t, n)
+	sets := make([]*SignatureSet, n)
+	for i := range sets {
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sets[i] = &SignatureSet{PublicKey: pks[i], Message: msg, Signature: Sign(sks[i], msg)}
+	}
+	return sets
+}
+
+func TestVerifyBatch(t *testing.T) {
+	sets := genSignatureSets(t, 9)
+
+	invalid, err := VerifyBatch(sets)
+	require.NoError(t, err)
+	require.Empty(t, invalid)
+
+	invalid, err = VerifyBatch(nil)
+	require.NoError(t, err)
+	require.Empty(t, invalid)
+}
+
+func TestVerifyBatchFindsInvalid(t *testing.T) {
+	sets := genSignatureSets(t, 9)
+	sets[2].Message = []byte("tampered")
+	sets[7].Signature = sets[6].Signature
+	sets[8].PublicKey = new(PublicKey)
+
+	invalid, err := VerifyBatch(sets)
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 7, 8}, invalid)
+}
+
+func TestVerifyBatchCancellingSignatures(t *testing.T) {
+	// Swapping the signatures of two sets that share a key keeps the plain
+	// aggregate valid, which the random weights must detect.
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msgA, msgB := []byte("a"), []byte("b")
+	sets := []*SignatureSet{
+		{PublicKey: pk, Message: msgA, Signature: Sign(sk, msgB)},
+		{PublicKey: pk, Message: msgB, Signature: Sign(sk, msgA)},
+	}
+
+	invalid, err := VerifyBatch(sets)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1}, invalid)
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	sets := make([]*SignatureSet, 128)
+	for i := range sets {
+		sk, pk, err := GenerateNewKeypair()
+		require.NoError(b, err)
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sets[i] = &SignatureSet{PublicKey: pk, Message: msg, Signature: Sign(sk, msg)}
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		invalid, err := VerifyBatch(sets)
+		require.NoError(b, err)
+		require.Empty(b, invalid)
+	}
+}
@@ -1,8 +1,8 @@
 package bls
 
 import (
+	"crypto/rand"
 	"errors"
-	"math/big"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -16,17 +16,18 @@ const (
 
 type (
 	PublicKey = bls12381.G1Affine
-	SecretKey = fr.Element
 	Signature = bls12381.G2Affine
 )
 
 var (
 	g1OneJac, _, g1One, _     = bls12381.Generators()
+	negG1One                  = *new(bls12381.G1Affine).Neg(&g1One)
 	domain                    = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
 	ErrInvalidPubkeyLength    = errors.New("invalid public key length")
 	ErrInvalidSecretKeyLength = errors.New("invalid secret key length")
 	ErrInvalidSignatureLength = errors.New("invalid signature length")
 	ErrSecretKeyIsZero        = errors.New("invalid secret key is zero")
+	ErrSecretKeyNotCanonical  = errors.New("invalid secret key is not less than the group order")
 )
 
 func PublicKeyToBytes(pk *PublicKey) []byte {
@@ -35,8 +36,13 @@ func PublicKeyToBytes(pk *PublicKey) []byte {
 }
 
 func SecretKeyToBytes(sk *SecretKey) []byte {
-	skBytes := sk.Bytes()
-	return skBytes[:]
+	skBytes := make([]byte, SecretKeyLength)
+	sk.withScalar(func(e *fr.Element) {
+		b := e.Bytes()
+		copy(skBytes, b[:])
+		clear(b[:])
+	})
+	return skBytes
 }
 
 func SignatureToBytes(sig *Signature) []byte {
@@ -44,46 +50,60 @@ func SignatureToBytes(sig *Signature) []byte {
 	return sigBytes[:]
 }
 
+// PublicKeyFromBytes decodes pkBytes with DecodeStrict.
 func PublicKeyFromBytes(pkBytes []byte) (*PublicKey, error) {
-	if len(pkBytes) != PublicKeyLength {
-		return nil, ErrInvalidPubkeyLength
-	}
-	pk := new(PublicKey)
-	err := pk.Unmarshal(pkBytes)
-	return pk, err
+	return DecodePublicKey(pkBytes, DecodeStrict)
 }
 
 func SecretKeyFromBytes(skBytes []byte) (*SecretKey, error) {
 	if len(skBytes) != SecretKeyLength {
 		return nil, ErrInvalidSecretKeyLength
 	}
-	sk := new(SecretKey).SetBytes(skBytes)
-	if sk.IsZero() {
+	e := new(fr.Element)
+	if err := e.SetBytesCanonical(skBytes); err != nil {
+		return nil, ErrSecretKeyNotCanonical
+	}
+	if e.IsZero() {
 		return nil, ErrSecretKeyIsZero
 	}
+	sk := newSecretKey(e)
+	e.SetZero()
 	return sk, nil
 }
 
+// SignatureFromBytes decodes sigBytes with DecodeStrict. It rejects the
+// point at infinity with ErrSignatureIsInfinity, which earlier versions
+// accepted. DecodeSignature rejects it in every mode, DecodeTrusted
+// included.
 func SignatureFromBytes(sigBytes []byte) (*Signature, error) {
-	if len(sigBytes) != SignatureLength {
-		return nil, ErrInvalidSignatureLength
-	}
-	sig := new(Signature)
-	err := sig.Unmarshal(sigBytes)
-	return sig, err
+	return DecodeSignature(sigBytes, DecodeStrict)
 }
 
+// GenerateRandomSecretKey runs KeyGen on MinSeedLength random bytes.
 func GenerateRandomSecretKey() (*SecretKey, error) {
-	return new(SecretKey).SetRandom()
+	ikm := make([]byte, MinSeedLength)
+	defer clear(ikm)
+	if _, err := rand.Read(ikm); err != nil {
+		return nil, err
+	}
+	return KeyGen(ikm, nil, nil)
 }
 
 func PublicKeyFromSecretKey(sk *SecretKey) (*PublicKey, error) {
-	if sk.IsZero() {
-		return nil, ErrSecretKeyIsZero
+	pkJac := new(bls12381.G1Jac)
+	err := ErrSecretKeyIsZero
+	sk.withScalar(func(e *fr.Element) {
+		if e.IsZero() {
+			return
+		}
+		b := scalarBigInt(e)
+		defer clearBigInt(b)
+		pkJac.ScalarMultiplication(&g1OneJac, b)
+		err = nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	skBigInt := new(big.Int)
-	sk.BigInt(skBigInt)
-	pkJac := new(bls12381.G1Jac).ScalarMultiplication(&g1OneJac, skBigInt)
 	return new(bls12381.G1Affine).FromJacobian(pkJac), nil
 }
 
@@ -99,33 +119,41 @@ func GenerateNewKeypair() (*SecretKey, *PublicKey, error) {
 	return sk, pk, nil
 }
 
+// Sign is like SignE but panics on error.
 func Sign(sk *SecretKey, msg []byte) *Signature {
-	Q, err := bls12381.HashToG2(msg, domain)
+	sig, err := SignE(sk, msg)
 	if err != nil {
 		panic(err)
 	}
-	skBigInt := new(big.Int)
-	sk.BigInt(skBigInt)
-	QJac := new(bls12381.G2Jac).FromAffine(&Q)
-	sigJac := new(bls12381.G2Jac).ScalarMultiplication(QJac, skBigInt)
-	return new(bls12381.G2Affine).FromJacobian(sigJac)
+	return sig
+}
+
+// SignE signs msg with sk, failing if sk is zero or msg cannot be hashed
+// to the curve.
+func SignE(sk *SecretKey, msg []byte) (*Signature, error) {
+	return coreSign(sk, msg, domain)
 }
 
 func VerifySignature(sig *Signature, pk *PublicKey, msg []byte) (bool, error) {
-	Q, err := bls12381.HashToG2(msg, domain)
-	if err != nil {
-		return false, err
-	}
-	var negP bls12381.G1Affine
-	negP.Neg(&g1One)
-	return bls12381.PairingCheck(
-		[]bls12381.G1Affine{*pk, negP},
-		[]bls12381.G2Affine{Q, *sig},
-	)
+	return coreVerify(sig, pk, msg, domain)
+}
+
+func coreSign(sk *SecretKey, msg, dst []byte) (*Signature, error) {
+	return backend.Sign(sk, msg, dst)
+}
+
+func coreVerify(sig *Signature, pk *PublicKey, msg, dst []byte) (bool, error) {
+	return backend.Verify(sig, pk, msg, dst)
 }
 
 func VerifySignatureBytes(msg, sigBytes, pkBytes []byte) (bool, error) {
-	pk, err := PublicKeyFromBytes(pkBytes)
+	return VerifySignatureBytesWithMode(msg, sigBytes, pkBytes, DecodeStrict)
+}
+
+// VerifySignatureBytesWithMode is like VerifySignatureBytes but decodes the
+// public key with mode. The signature is always decoded strictly.
+func VerifySignatureBytesWithMode(msg, sigBytes, pkBytes []byte, mode DecodeMode) (bool, error) {
+	pk, err := DecodePublicKey(pkBytes, mode)
 	if err != nil {
 		return false, err
 	}
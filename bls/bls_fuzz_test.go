@@ -53,12 +53,15 @@ func FuzzPublicKeyFromSecretKey(f *testing.F) {
 		if err != nil {
 			return
 		}
-		var sk SecretKey
-		err = tp.Fill(&sk)
+		skBytes, err := tp.GetNBytes(SecretKeyLength)
 		if err != nil {
 			return
 		}
-		pk, err := PublicKeyFromSecretKey(&sk)
+		sk, err := SecretKeyFromBytes(skBytes)
+		if err != nil {
+			return
+		}
+		pk, err := PublicKeyFromSecretKey(sk)
 		if err != nil {
 			require.NotNil(t, pk)
 		}
@@ -111,8 +114,11 @@ func FuzzSign(f *testing.F) {
 		if err != nil {
 			return
 		}
-		var sk SecretKey
-		err = tp.Fill(&sk)
+		skBytes, err := tp.GetNBytes(SecretKeyLength)
+		if err != nil {
+			return
+		}
+		sk, err := SecretKeyFromBytes(skBytes)
 		if err != nil {
 			return
 		}
@@ -120,7 +126,8 @@ func FuzzSign(f *testing.F) {
 		if err != nil {
 			return
 		}
-		Sign(&sk, msg)
+		_, err = SignE(sk, msg)
+		require.NoError(t, err)
 	})
 }
 
@@ -35,3 +35,15 @@ func TestSignatureVerifyRealValues(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, result, true)
 }
+
+func TestSecretKeyFromBytesNotCanonical(t *testing.T) {
+	// The group order r and r+1, which would otherwise be reduced to 0 and 1.
+	r := hexutil.MustDecode("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	_, err := SecretKeyFromBytes(r)
+	require.ErrorIs(t, err, ErrSecretKeyNotCanonical)
+	r[len(r)-1]++
+	_, err = SecretKeyFromBytes(r)
+	require.ErrorIs(t, err, ErrSecretKeyNotCanonical)
+	_, err = SecretKeyFromBytes(make([]byte, SecretKeyLength))
+	require.ErrorIs(t, err, ErrSecretKeyIsZero)
+}
@@ -0,0 +1,111 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Ciphersuite is one of the BLS signature schemes of
+// draft-irtf-cfrg-bls-signature with public keys in G1 and signatures in
+// G2. The package-level functions use CiphersuitePop, as Ethereum does.
+type Ciphersuite int
+
+const (
+	// CiphersuitePop is the proof-of-possession scheme. Rogue key attacks
+	// are prevented by verifying a PopProve proof for every public key.
+	CiphersuitePop Ciphersuite = iota
+	// CiphersuiteBasic is the basic scheme. Rogue key attacks are prevented
+	// by requiring distinct messages in an aggregate.
+	CiphersuiteBasic
+	// CiphersuiteAug is the message augmentation scheme. Rogue key attacks
+	// are prevented by prepending the signer's public key to the message.
+	CiphersuiteAug
+)
+
+var (
+	basicDomain = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+	augDomain   = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+
+	ErrUnknownCiphersuite = errors.New("unknown ciphersuite")
+)
+
+func (c Ciphersuite) String() string {
+	switch c {
+	case CiphersuitePop:
+		return "POP"
+	case CiphersuiteBasic:
+		return "NUL"
+	case CiphersuiteAug:
+		return "AUG"
+	default:
+		return fmt.Sprintf("Ciphersuite(%d)", int(c))
+	}
+}
+
+// DST returns the domain separation tag used for hashing messages to G2.
+func (c Ciphersuite) DST() ([]byte, error) {
+	switch c {
+	case CiphersuitePop:
+		return domain, nil
+	case CiphersuiteBasic:
+		return basicDomain, nil
+	case CiphersuiteAug:
+		return augDomain, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownCiphersuite, int(c))
+	}
+}
+
+// Sign signs msg with sk. With CiphersuiteAug the public key of sk is
+// prepended to msg.
+func (c Ciphersuite) Sign(sk *SecretKey, msg []byte) (*Signature, error) {
+	dst, err := c.DST()
+	if err != nil {
+		return nil, err
+	}
+	if c == CiphersuiteAug {
+		pk, err := PublicKeyFromSecretKey(sk)
+		if err != nil {
+			return nil, err
+		}
+		msg = augmentMessage(pk, msg)
+	}
+	return coreSign(sk, msg, dst)
+}
+
+// Verify checks that sig is a signature of msg by pk. With CiphersuiteAug
+// pk is prepended to msg.
+func (c Ciphersuite) Verify(sig *Signature, pk *PublicKey, msg []byte) (bool, error) {
+	dst, err := c.DST()
+	if err != nil {
+		return false, err
+	}
+	if c == CiphersuiteAug {
+		msg = augmentMessage(pk, msg)
+	}
+	return coreVerify(sig, pk, msg, dst)
+}
+
+// AggregateVerify verifies an aggregate signature where pks[i] signed
+// msgs[i]. The messages must be distinct, except with CiphersuiteAug,
+// which allows any messages including repeated pairs of public key and
+// message.
+func (c Ciphersuite) AggregateVerify(sig *Signature, pks []*PublicKey, msgs [][]byte) (bool, error) {
+	dst, err := c.DST()
+	if err != nil {
+		return false, err
+	}
+	if c == CiphersuiteAug && len(pks) == len(msgs) {
+		augmented := make([][]byte, len(msgs))
+		for i, msg := range msgs {
+			augmented[i] = augmentMessage(pks[i], msg)
+		}
+		msgs = augmented
+	}
+	return coreAggregateVerify(sig, pks, msgs, dst, c != CiphersuiteAug)
+}
+
+func augmentMessage(pk *PublicKey, msg []byte) []byte {
+	pkBytes := pk.Bytes()
+	return append(pkBytes[:], msg...)
+}
@@ -0,0 +1,110 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var ciphersuites = []Ciphersuite{CiphersuitePop, CiphersuiteBasic, CiphersuiteAug}
+
+func TestCiphersuiteSignVerify(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msg := []byte("message")
+
+	sigs := make(map[Ciphersuite]*Signature)
+	for _, c := range ciphersuites {
+		sig, err := c.Sign(sk, msg)
+		require.NoError(t, err, c)
+		sigs[c] = sig
+
+		ok, err := c.Verify(sig, pk, msg)
+		require.NoError(t, err, c)
+		require.True(t, ok, c)
+		ok, err = c.Verify(sig, pk, []byte("other message"))
+		require.NoError(t, err, c)
+		require.False(t, ok, c)
+	}
+
+	// The default functions are the POP ciphersuite.
+	require.True(t, Sign(sk, msg).Equal(sigs[CiphersuitePop]))
+
+	// Signatures do not verify under another ciphersuite.
+	for _, c := range ciphersuites {
+		for _, other := range ciphersuites {
+			if c == other {
+				continue
+			}
+			require.False(t, sigs[c].Equal(sigs[other]))
+			ok, err := other.Verify(sigs[c], pk, msg)
+			require.NoError(t, err)
+			require.False(t, ok, "%s signature verified as %s", c, other)
+		}
+	}
+
+	// AUG signs the public key followed by the message.
+	ok, err := coreVerify(sigs[CiphersuiteAug], pk, append(PublicKeyToBytes(pk), msg...), augDomain)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestCiphersuiteAggregateVerify(t *testing.T) {
+	sks, pks := genKeypairs(t, 3)
+	for _, c := range ciphersuites {
+		msgs := make([][]byte, len(sks))
+		sigs := make([]*Signature, len(sks))
+		for i, sk := range sks {
+			msgs[i] = []byte(fmt.Sprintf("message %d", i))
+			var err error
+			sigs[i], err = c.Sign(sk, msgs[i])
+			require.NoError(t, err)
+		}
+		aggSig, err := AggregateSignatures(sigs)
+		require.NoError(t, err)
+
+		ok, err := c.AggregateVerify(aggSig, pks, msgs)
+		require.NoError(t, err, c)
+		require.True(t, ok, c)
+
+		msgs[2] = msgs[0]
+		_, err = c.AggregateVerify(aggSig, pks, msgs)
+		if c == CiphersuiteAug {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, ErrDuplicateMessage, c)
+		}
+	}
+}
+
+func TestAugAggregateVerifyRepeatedPair(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msg := []byte("message")
+	sig, err := CiphersuiteAug.Sign(sk, msg)
+	require.NoError(t, err)
+	aggSig, err := AggregateSignatures([]*Signature{sig, sig})
+	require.NoError(t, err)
+
+	ok, err := CiphersuiteAug.AggregateVerify(aggSig, []*PublicKey{pk, pk}, [][]byte{msg, msg})
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = CiphersuiteAug.AggregateVerify(sig, []*PublicKey{pk, pk}, [][]byte{msg, msg})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestUnknownCiphersuite(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	c := Ciphersuite(42)
+	require.Equal(t, "Ciphersuite(42)", c.String())
+
+	_, err = c.Sign(sk, nil)
+	require.ErrorIs(t, err, ErrUnknownCiphersuite)
+	_, err = c.Verify(Sign(sk, nil), pk, nil)
+	require.ErrorIs(t, err, ErrUnknownCiphersuite)
+	_, err = c.AggregateVerify(Sign(sk, nil), []*PublicKey{pk}, [][]byte{nil})
+	require.ErrorIs(t, err, ErrUnknownCiphersuite)
+}
@@ -0,0 +1,105 @@
+package bls
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// DecodeMode selects the checks performed when decoding public keys and
+// signatures.
+type DecodeMode int
+
+const (
+	// DecodeStrict rejects the point at infinity and checks that the point
+	// is in the prime-order subgroup. Use it for anything received from the
+	// outside world.
+	DecodeStrict DecodeMode = iota
+	// DecodeTrusted still rejects malformed encodings and the point at
+	// infinity but skips the comparatively expensive subgroup check. Only
+	// use it for points that were strictly decoded before and stored by us.
+	DecodeTrusted
+)
+
+var (
+	ErrInvalidPointEncoding   = errors.New("cannot decode point")
+	ErrPubkeyIsInfinity       = errors.New("invalid public key is the point at infinity")
+	ErrPubkeyNotInSubgroup    = errors.New("invalid public key is not in the G1 subgroup")
+	ErrSignatureIsInfinity    = errors.New("invalid signature is the point at infinity")
+	ErrSignatureNotInSubgroup = errors.New("invalid signature is not in the G2 subgroup")
+	ErrUnknownDecodeMode      = errors.New("unknown decode mode")
+	errTrailingBytes          = errors.New("trailing bytes after point")
+)
+
+// DecodePublicKey decodes a compressed public key, performing the checks
+// of mode. Failed checks are reported as ErrInvalidPubkeyLength,
+// ErrInvalidPointEncoding, ErrPubkeyIsInfinity or ErrPubkeyNotInSubgroup.
+func DecodePublicKey(pkBytes []byte, mode DecodeMode) (*PublicKey, error) {
+	if len(pkBytes) != PublicKeyLength {
+		return nil, ErrInvalidPubkeyLength
+	}
+	pk := new(PublicKey)
+	if err := decodePoint(pkBytes, pk); err != nil {
+		return nil, err
+	}
+	if pk.IsInfinity() {
+		return nil, ErrPubkeyIsInfinity
+	}
+	// (0, ±2) has order 3. Rejecting it even in trusted mode costs nothing
+	// and matches blst.
+	if pk.X.IsZero() {
+		return nil, ErrPubkeyNotInSubgroup
+	}
+	switch mode {
+	case DecodeStrict:
+		if !pk.IsInSubGroup() {
+			return nil, ErrPubkeyNotInSubgroup
+		}
+	case DecodeTrusted:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownDecodeMode, mode)
+	}
+	return pk, nil
+}
+
+// DecodeSignature decodes a compressed signature, performing the checks
+// of mode. Failed checks are reported as ErrInvalidSignatureLength,
+// ErrInvalidPointEncoding, ErrSignatureIsInfinity or
+// ErrSignatureNotInSubgroup.
+func DecodeSignature(sigBytes []byte, mode DecodeMode) (*Signature, error) {
+	if len(sigBytes) != SignatureLength {
+		return nil, ErrInvalidSignatureLength
+	}
+	sig := new(Signature)
+	if err := decodePoint(sigBytes, sig); err != nil {
+		return nil, err
+	}
+	if sig.IsInfinity() {
+		return nil, ErrSignatureIsInfinity
+	}
+	switch mode {
+	case DecodeStrict:
+		if !sig.IsInSubGroup() {
+			return nil, ErrSignatureNotInSubgroup
+		}
+	case DecodeTrusted:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownDecodeMode, mode)
+	}
+	return sig, nil
+}
+
+// decodePoint decodes b into p without a subgroup check, which callers
+// perform depending on the DecodeMode.
+func decodePoint(b []byte, p any) error {
+	r := bytes.NewReader(b)
+	if err := bls12381.NewDecoder(r, bls12381.NoSubgroupChecks()).Decode(p); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPointEncoding, err)
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidPointEncoding, errTrailingBytes)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// Compressed points that are on the curve but outside the prime-order
+	// subgroup.
+	g1NotInSubgroup = hexutil.MustDecode("0x800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004")
+	g2NotInSubgroup = hexutil.MustDecode("0x800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002")
+	// Compressed x-coordinates without a matching y on the curve.
+	g1NotOnCurve = hexutil.MustDecode("0xed7f862045422bd51ba732730ce993c94d2545e5db1112102026343904fcdf6f5cf37926a3688444703772ed80fa223f")
+)
+
+func infinityBytes(n int) []byte {
+	b := make([]byte, n)
+	b[0] = 0xc0
+	return b
+}
+
+func TestDecodePublicKey(t *testing.T) {
+	_, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		Name    string
+		Input   []byte
+		Strict  error
+		Trusted error
+	}{
+		{Name: "valid", Input: PublicKeyToBytes(pk)},
+		{Name: "short", Input: make([]byte, PublicKeyLength-1), Strict: ErrInvalidPubkeyLength, Trusted: ErrInvalidPubkeyLength},
+		{Name: "uncompressed flag", Input: make([]byte, PublicKeyLength), Strict: ErrInvalidPointEncoding, Trusted: ErrInvalidPointEncoding},
+		{Name: "not on curve", Input: g1NotOnCurve, Strict: ErrInvalidPointEncoding, Trusted: ErrInvalidPointEncoding},
+		{Name: "infinity", Input: infinityBytes(PublicKeyLength), Strict: ErrPubkeyIsInfinity, Trusted: ErrPubkeyIsInfinity},
+		{Name: "not in subgroup", Input: g1NotInSubgroup, Strict: ErrPubkeyNotInSubgroup},
+		{Name: "zero x", Input: append([]byte{0x80}, make([]byte, PublicKeyLength-1)...), Strict: ErrPubkeyNotInSubgroup, Trusted: ErrPubkeyNotInSubgroup},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			for mode, expected := range map[DecodeMode]error{DecodeStrict: tc.Strict, DecodeTrusted: tc.Trusted} {
+				decoded, err := DecodePublicKey(tc.Input, mode)
+				if expected != nil {
+					require.ErrorIs(t, err, expected)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.Input, PublicKeyToBytes(decoded))
+			}
+		})
+	}
+
+	_, err = DecodePublicKey(PublicKeyToBytes(pk), DecodeMode(-1))
+	require.ErrorIs(t, err, ErrUnknownDecodeMode)
+}
+
+func TestDecodeSignature(t *testing.T) {
+	sk, _, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	sig := Sign(sk, []byte("message"))
+
+	for _, tc := range []struct {
+		Name    string
+		Input   []byte
+		Strict  error
+		Trusted error
+	}{
+		{Name: "valid", Input: SignatureToBytes(sig)},
+		{Name: "short", Input: make([]byte, SignatureLength-1), Strict: ErrInvalidSignatureLength, Trusted: ErrInvalidSignatureLength},
+		{Name: "uncompressed flag", Input: make([]byte, SignatureLength), Strict: ErrInvalidPointEncoding, Trusted: ErrInvalidPointEncoding},
+		{Name: "infinity", Input: infinityBytes(SignatureLength), Strict: ErrSignatureIsInfinity, Trusted: ErrSignatureIsInfinity},
+		{Name: "not in subgroup", Input: g2NotInSubgroup, Strict: ErrSignatureNotInSubgroup},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			for mode, expected := range map[DecodeMode]error{DecodeStrict: tc.Strict, DecodeTrusted: tc.Trusted} {
+				decoded, err := DecodeSignature(tc.Input, mode)
+				if expected != nil {
+					require.ErrorIs(t, err, expected)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.Input, SignatureToBytes(decoded))
+			}
+		})
+	}
+}
+
+func TestVerifySignatureBytesWithMode(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msg := []byte("message")
+	sigBytes := SignatureToBytes(Sign(sk, msg))
+
+	for _, mode := range []DecodeMode{DecodeStrict, DecodeTrusted} {
+		ok, err := VerifySignatureBytesWithMode(msg, sigBytes, PublicKeyToBytes(pk), mode)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	_, err = VerifySignatureBytes(msg, sigBytes, g1NotInSubgroup)
+	require.ErrorIs(t, err, ErrPubkeyNotInSubgroup)
+	_, err = VerifySignatureBytes(msg, infinityBytes(SignatureLength), PublicKeyToBytes(pk))
+	require.ErrorIs(t, err, ErrSignatureIsInfinity)
+}
@@ -0,0 +1,105 @@
+package bls
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Key derivation as specified by EIP-2333 and EIP-2334:
+// https://eips.ethereum.org/EIPS/eip-2333
+// https://eips.ethereum.org/EIPS/eip-2334
+
+const (
+	lamportChunks   = 255
+	lamportChunkLen = sha256.Size
+)
+
+var ErrInvalidKeyPath = errors.New("invalid key derivation path")
+
+// DeriveMasterSecretKey derives the root key of an EIP-2333 tree from seed.
+func DeriveMasterSecretKey(seed []byte) (*SecretKey, error) {
+	return KeyGen(seed, nil, nil)
+}
+
+// DeriveChildSecretKey derives the child of parent at index.
+func DeriveChildSecretKey(parent *SecretKey, index uint32) (*SecretKey, error) {
+	compressedLamportPK, err := parentSecretKeyToLamportPK(parent, index)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(compressedLamportPK)
+	return keyGen(compressedLamportPK, defaultKeyGenSalt[:], nil)
+}
+
+// DeriveSecretKeyFromPath derives the key at an EIP-2334 path such as
+// m/12381/3600/0/0/0 from seed.
+func DeriveSecretKeyFromPath(seed []byte, path string) (*SecretKey, error) {
+	indices, err := ParseKeyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	sk, err := DeriveMasterSecretKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		sk, err = DeriveChildSecretKey(sk, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sk, nil
+}
+
+// ParseKeyPath splits an EIP-2334 path into its child indices. The leading
+// "m" denotes the master key and is not part of the result.
+func ParseKeyPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, ErrInvalidKeyPath
+	}
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidKeyPath
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+func parentSecretKeyToLamportPK(parent *SecretKey, index uint32) ([]byte, error) {
+	salt := binary.BigEndian.AppendUint32(nil, index)
+	var ikm [SecretKeyLength]byte
+	parent.withScalar(func(e *fr.Element) { ikm = e.Bytes() })
+	defer clear(ikm[:])
+	notIkm := make([]byte, len(ikm))
+	defer clear(notIkm)
+	for i, b := range ikm {
+		notIkm[i] = ^b
+	}
+
+	lamportPK := make([]byte, 0, 2*lamportChunks*lamportChunkLen)
+	defer clear(lamportPK)
+	for _, secret := range [][]byte{ikm[:], notIkm} {
+		okm, err := hkdf.Key(sha256.New, secret, salt, "", lamportChunks*lamportChunkLen)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < lamportChunks; i++ {
+			chunk := sha256.Sum256(okm[i*lamportChunkLen : (i+1)*lamportChunkLen])
+			lamportPK = append(lamportPK, chunk[:]...)
+			clear(chunk[:])
+		}
+		clear(okm)
+	}
+	compressed := sha256.Sum256(lamportPK)
+	return compressed[:], nil
+}
@@ -0,0 +1,109 @@
+package bls
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func mustBigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer " + s)
+	}
+	return n
+}
+
+// Test cases from https://eips.ethereum.org/EIPS/eip-2333#test-cases
+func TestDeriveSecretKeyEIP2333(t *testing.T) {
+	for _, tc := range []struct {
+		Seed       []byte
+		MasterSK   string
+		ChildIndex uint32
+		ChildSK    string
+	}{
+		{
+			Seed:       hexutil.MustDecode("0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"),
+			MasterSK:   "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+			ChildIndex: 0,
+			ChildSK:    "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+		},
+		{
+			Seed:       hexutil.MustDecode("0x3141592653589793238462643383279502884197169399375105820974944592"),
+			MasterSK:   "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+			ChildIndex: 3141592653,
+			ChildSK:    "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+		},
+		{
+			Seed:       hexutil.MustDecode("0x0099FF991111002299DD7744EE3355BBDD8844115566CC55663355668888CC00"),
+			MasterSK:   "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+			ChildIndex: 4294967295,
+			ChildSK:    "29358610794459428860402234341874281240803786294062035874021252734817515685787",
+		},
+		{
+			Seed:       hexutil.MustDecode("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"),
+			MasterSK:   "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+			ChildIndex: 42,
+			ChildSK:    "31372231650479070279774297061823572166496564838472787488249775572789064611981",
+		},
+	} {
+		master, err := DeriveMasterSecretKey(tc.Seed)
+		require.NoError(t, err)
+		require.Equal(t, mustBigInt(tc.MasterSK), new(big.Int).SetBytes(SecretKeyToBytes(master)))
+
+		child, err := DeriveChildSecretKey(master, tc.ChildIndex)
+		require.NoError(t, err)
+		require.Equal(t, mustBigInt(tc.ChildSK), new(big.Int).SetBytes(SecretKeyToBytes(child)))
+	}
+}
+
+func TestDeriveMasterSecretKeyShortSeed(t *testing.T) {
+	_, err := DeriveMasterSecretKey(make([]byte, 31))
+	require.ErrorIs(t, err, ErrSeedTooShort)
+}
+
+func TestParseKeyPath(t *testing.T) {
+	for _, tc := range []struct {
+		Path    string
+		Indices []uint32
+		Err     error
+	}{
+		{Path: "m", Indices: []uint32{}},
+		{Path: "m/12381/3600/0/0/0", Indices: []uint32{12381, 3600, 0, 0, 0}},
+		{Path: "m/12381/3600/4294967295/0", Indices: []uint32{12381, 3600, 4294967295, 0}},
+		{Path: "", Err: ErrInvalidKeyPath},
+		{Path: "12381/3600/0/0/0", Err: ErrInvalidKeyPath},
+		{Path: "m/12381/3600/0/0/", Err: ErrInvalidKeyPath},
+		{Path: "m/12381/3600/-1/0/0", Err: ErrInvalidKeyPath},
+		{Path: "m/12381/3600/4294967296/0/0", Err: ErrInvalidKeyPath},
+	} {
+		indices, err := ParseKeyPath(tc.Path)
+		if tc.Err != nil {
+			require.ErrorIs(t, err, tc.Err, tc.Path)
+			continue
+		}
+		require.NoError(t, err, tc.Path)
+		require.Equal(t, tc.Indices, indices)
+	}
+}
+
+func TestDeriveSecretKeyFromPath(t *testing.T) {
+	seed := hexutil.MustDecode("0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+
+	sk, err := DeriveSecretKeyFromPath(seed, "m/0")
+	require.NoError(t, err)
+	require.Equal(t, mustBigInt("20397789859736650942317412262472558107875392172444076792671091975210932703118"), new(big.Int).SetBytes(SecretKeyToBytes(sk)))
+
+	master, err := DeriveMasterSecretKey(seed)
+	require.NoError(t, err)
+	expected := master
+	for _, index := range []uint32{12381, 3600, 7, 0, 0} {
+		expected, err = DeriveChildSecretKey(expected, index)
+		require.NoError(t, err)
+	}
+	sk, err = DeriveSecretKeyFromPath(seed, "m/12381/3600/7/0/0")
+	require.NoError(t, err)
+	require.True(t, expected.Equal(sk))
+}
@@ -0,0 +1,141 @@
+//go:build blst && cgo
+
+package bls
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// Differential fuzz targets feeding the same inputs to this package, with
+// the gnark backend, and to blst directly. Any disagreement on acceptance,
+// encoding or verification fails the target.
+
+var (
+	seedSecretKey  = hexutil.MustDecode("0x263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")
+	seedGroupOrder = hexutil.MustDecode("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+)
+
+func FuzzDifferentialSecretKey(f *testing.F) {
+	f.Add(seedSecretKey, []byte("message"))
+	f.Add(seedGroupOrder, []byte{})
+	f.Add(make([]byte, SecretKeyLength), []byte{})
+	f.Fuzz(func(t *testing.T, skBytes, msg []byte) {
+		sk, err := SecretKeyFromBytes(skBytes)
+		blstSk := new(blst.SecretKey).Deserialize(skBytes)
+		require.Equal(t, blstSk != nil, err == nil, "secret key acceptance: %v", err)
+		if err != nil {
+			return
+		}
+		require.Equal(t, blstSk.Serialize(), SecretKeyToBytes(sk))
+
+		pk, err := PublicKeyFromSecretKey(sk)
+		require.NoError(t, err)
+		blstPk := new(blst.P1Affine).From(blstSk)
+		require.Equal(t, blstPk.Compress(), PublicKeyToBytes(pk))
+
+		sig, err := GnarkBackend().Sign(sk, msg, domain)
+		require.NoError(t, err)
+		blstSig := new(blst.P2Affine).Sign(blstSk, msg, domain)
+		require.Equal(t, blstSig.Compress(), SignatureToBytes(sig))
+
+		ok, err := GnarkBackend().Verify(sig, pk, msg, domain)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, blstSig.Verify(true, blstPk, true, msg, domain))
+	})
+}
+
+func FuzzDifferentialPublicKey(f *testing.F) {
+	f.Add(g1NotInSubgroup)
+	f.Add(g1NotOnCurve)
+	f.Add(infinityBytes(PublicKeyLength))
+	f.Add(make([]byte, PublicKeyLength))
+	f.Fuzz(func(t *testing.T, pkBytes []byte) {
+		blstPk := new(blst.P1Affine).Uncompress(pkBytes)
+		decoded := blstPk != nil && !bytes.Equal(pkBytes, infinityBytes(PublicKeyLength))
+
+		pk, err := DecodePublicKey(pkBytes, DecodeTrusted)
+		require.Equal(t, decoded, err == nil, "trusted acceptance: %v", err)
+		if err == nil {
+			require.Equal(t, pkBytes, PublicKeyToBytes(pk))
+			require.Equal(t, blstPk.Compress(), PublicKeyToBytes(pk))
+		}
+
+		_, err = DecodePublicKey(pkBytes, DecodeStrict)
+		require.Equal(t, blstPk != nil && blstPk.KeyValidate(), err == nil, "strict acceptance: %v", err)
+	})
+}
+
+func FuzzDifferentialSignature(f *testing.F) {
+	f.Add(g2NotInSubgroup)
+	f.Add(infinityBytes(SignatureLength))
+	f.Add(make([]byte, SignatureLength))
+	f.Fuzz(func(t *testing.T, sigBytes []byte) {
+		blstSig := new(blst.P2Affine).Uncompress(sigBytes)
+		decoded := blstSig != nil && !bytes.Equal(sigBytes, infinityBytes(SignatureLength))
+
+		sig, err := DecodeSignature(sigBytes, DecodeTrusted)
+		require.Equal(t, decoded, err == nil, "trusted acceptance: %v", err)
+		if err == nil {
+			require.Equal(t, sigBytes, SignatureToBytes(sig))
+			require.Equal(t, blstSig.Compress(), SignatureToBytes(sig))
+		}
+
+		_, err = DecodeSignature(sigBytes, DecodeStrict)
+		require.Equal(t, blstSig != nil && blstSig.SigValidate(true), err == nil, "strict acceptance: %v", err)
+	})
+}
+
+// FuzzDifferentialVerify signs msg with a key derived from skBytes and then
+// optionally corrupts the signature, public key or message at a fuzzed
+// position before verifying with both implementations.
+func FuzzDifferentialVerify(f *testing.F) {
+	f.Add(seedSecretKey, []byte("message"), uint8(0), uint16(0), uint8(0))
+	f.Add(seedSecretKey, []byte("message"), uint8(1), uint16(5), uint8(0x01))
+	f.Add(seedSecretKey, []byte("message"), uint8(2), uint16(47), uint8(0x80))
+	f.Add(seedSecretKey, []byte("message"), uint8(3), uint16(3), uint8(0xff))
+	f.Fuzz(func(t *testing.T, skBytes, msg []byte, target uint8, pos uint16, mask uint8) {
+		sk, err := SecretKeyFromBytes(skBytes)
+		if err != nil {
+			return
+		}
+		pk, err := PublicKeyFromSecretKey(sk)
+		require.NoError(t, err)
+		sig, err := SignE(sk, msg)
+		require.NoError(t, err)
+
+		pkBytes, sigBytes, msg := PublicKeyToBytes(pk), SignatureToBytes(sig), bytes.Clone(msg)
+		corrupt := func(b []byte) {
+			if len(b) > 0 {
+				b[int(pos)%len(b)] ^= mask
+			}
+		}
+		switch target % 4 {
+		case 1:
+			corrupt(sigBytes)
+		case 2:
+			corrupt(pkBytes)
+		case 3:
+			corrupt(msg)
+		}
+
+		expected := new(blst.P2Affine).VerifyCompressed(sigBytes, true, pkBytes, true, msg, domain)
+		ok, err := func() (bool, error) {
+			pk, err := PublicKeyFromBytes(pkBytes)
+			if err != nil {
+				return false, err
+			}
+			sig, err := SignatureFromBytes(sigBytes)
+			if err != nil {
+				return false, err
+			}
+			return GnarkBackend().Verify(sig, pk, msg, domain)
+		}()
+		require.Equal(t, expected, ok && err == nil, "verification: %v", err)
+	})
+}
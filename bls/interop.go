@@ -0,0 +1,37 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// InteropKeys returns the count deterministic interop keys starting at
+// index start, which devnets and tests share as known validator keys:
+// https://github.com/ethereum/eth2.0-pm/tree/master/interop/mocked_start
+// The secret key of index i is sha256 of i as a 32-byte little-endian
+// integer, read as a little-endian integer modulo the curve order. They
+// are public and must never secure real funds.
+func InteropKeys(start, count uint64) ([]*SecretKey, []*PublicKey, error) {
+	sks := make([]*SecretKey, count)
+	pks := make([]*PublicKey, count)
+	var index [32]byte
+	e := new(fr.Element)
+	for i := range count {
+		binary.LittleEndian.PutUint64(index[:], start+i)
+		h := sha256.Sum256(index[:])
+		slices.Reverse(h[:])
+		e.SetBytes(h[:])
+		sks[i] = newSecretKey(e)
+
+		pk, err := PublicKeyFromSecretKey(sks[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		pks[i] = pk
+	}
+	e.SetZero()
+	return sks, pks, nil
+}
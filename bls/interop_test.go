@@ -0,0 +1,50 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from keygen_10_validators.yaml of
+// https://github.com/ethereum/eth2.0-pm/tree/master/interop/mocked_start
+var interopKeys = []struct {
+	SK string
+	PK string
+}{
+	{
+		SK: "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+		PK: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+	},
+	{
+		SK: "0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000",
+		PK: "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+	},
+	{
+		SK: "0x315ed405fafe339603932eebe8dbfd650ce5dafa561f6928664c75db85f97857",
+		PK: "0xa3a32b0f8b4ddb83f1a0a853d81dd725dfe577d4f4c3db8ece52ce2b026eca84815c1a7e8e92a4de3d755733bf7e4a9b",
+	},
+}
+
+func TestInteropKeys(t *testing.T) {
+	sks, pks, err := InteropKeys(0, uint64(len(interopKeys)))
+	require.NoError(t, err)
+	require.Len(t, sks, len(interopKeys))
+	require.Len(t, pks, len(interopKeys))
+	for i, tc := range interopKeys {
+		require.Equal(t, tc.SK, hexutil.Encode(SecretKeyToBytes(sks[i])))
+		require.Equal(t, tc.PK, hexutil.Encode(PublicKeyToBytes(pks[i])))
+	}
+
+	// Keys depend on the index only.
+	sks, pks, err = InteropKeys(2, 1)
+	require.NoError(t, err)
+	require.Equal(t, interopKeys[2].SK, hexutil.Encode(SecretKeyToBytes(sks[0])))
+	require.Equal(t, interopKeys[2].PK, hexutil.Encode(PublicKeyToBytes(pks[0])))
+
+	sks, pks, err = InteropKeys(5, 0)
+	require.NoError(t, err)
+	require.Empty(t, sks)
+	require.Empty(t, pks)
+}
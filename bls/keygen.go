@@ -0,0 +1,73 @@
+package bls
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// MinSeedLength is the minimum length of the input keying material of
+// KeyGen and the seed of DeriveMasterSecretKey.
+const MinSeedLength = 32
+
+var (
+	// defaultKeyGenSalt is H("BLS-SIG-KEYGEN-SALT-"), the salt of
+	// draft-irtf-cfrg-bls-signature-05 and, after its initial rehash, of
+	// draft 04 and EIP-2333.
+	defaultKeyGenSalt = sha256.Sum256([]byte("BLS-SIG-KEYGEN-SALT-"))
+
+	ErrSeedTooShort = errors.New("seed must be at least 32 bytes")
+)
+
+// KeyGen derives a secret key from at least MinSeedLength bytes of input
+// keying material as specified by KeyGen in section 2.3 of
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05.
+// A nil salt selects the default salt, which makes the result match
+// EIP-2333 master keys and the KeyGen of blst, py_ecc and Lighthouse.
+func KeyGen(ikm, salt, keyInfo []byte) (*SecretKey, error) {
+	if len(ikm) < MinSeedLength {
+		return nil, ErrSeedTooShort
+	}
+	if salt == nil {
+		salt = defaultKeyGenSalt[:]
+	}
+	return keyGen(ikm, salt, keyInfo)
+}
+
+func keyGen(ikm, salt, keyInfo []byte) (*SecretKey, error) {
+	const L = 48
+	info := binary.BigEndian.AppendUint16(append([]byte{}, keyInfo...), L)
+	ikm = append(append([]byte{}, ikm...), 0)
+	defer clear(ikm)
+
+	okmInt := new(big.Int)
+	defer clearBigInt(okmInt)
+	e := new(fr.Element)
+	defer e.SetZero()
+	for {
+		prk, err := hkdf.Extract(sha256.New, ikm, salt)
+		if err != nil {
+			return nil, err
+		}
+		okm, err := hkdf.Expand(sha256.New, prk, string(info), L)
+		clear(prk)
+		if err != nil {
+			return nil, err
+		}
+		okmInt.SetBytes(okm)
+		clear(okm)
+		okmInt.Mod(okmInt, fr.Modulus())
+		e.SetBigInt(okmInt)
+		if !e.IsZero() {
+			break
+		}
+		h := sha256.Sum256(salt)
+		salt = h[:]
+	}
+	sk := newSecretKey(e)
+	return sk, nil
+}
@@ -0,0 +1,60 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Expected keys were produced with blst's KeyGen (nil salt) and KeyGenV5.
+// The second case is the master key of the first EIP-2333 test case.
+func TestKeyGen(t *testing.T) {
+	for _, tc := range []struct {
+		IKM     string
+		Salt    []byte
+		KeyInfo []byte
+		SK      string
+	}{
+		{
+			IKM: "0x0000000000000000000000000000000000000000000000000000000000000000",
+			SK:  "0x4d129a19df86a0f5345bad4cc6f249ec2a819ccc3386895beb4f7d98b3db6235",
+		},
+		{
+			IKM: "0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			SK:  "0x0d7359d57963ab8fbbde1852dcf553fedbc31f464d80ee7d40ae683122b45070",
+		},
+		{
+			IKM:     "0x3141592653589793238462643383279502884197169399375105820974944592",
+			KeyInfo: []byte("key info"),
+			SK:      "0x131960a1c78b57c8fdbc334bb819728a991713a7be21b90c324e64aa61ec4037",
+		},
+		{
+			IKM:  "0x0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+			Salt: []byte("custom salt"),
+			SK:   "0x4117789bad2c6e561c78b735bebe6befd6621ded53a561587c8cd42d5e3dd0cb",
+		},
+		{
+			IKM:     "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			Salt:    []byte("custom salt"),
+			KeyInfo: []byte("key info"),
+			SK:      "0x4d9fa7e97096a68b9e6f3c4ae82b4f14bedba383ec2c94cd9051525ee366c253",
+		},
+	} {
+		sk, err := KeyGen(hexutil.MustDecode(tc.IKM), tc.Salt, tc.KeyInfo)
+		require.NoError(t, err)
+		require.Equal(t, tc.SK, hexutil.Encode(SecretKeyToBytes(sk)))
+	}
+
+	_, err := KeyGen(make([]byte, MinSeedLength-1), nil, nil)
+	require.ErrorIs(t, err, ErrSeedTooShort)
+}
+
+func TestKeyGenDefaultSalt(t *testing.T) {
+	ikm := make([]byte, MinSeedLength)
+	sk, err := KeyGen(ikm, nil, nil)
+	require.NoError(t, err)
+	sk2, err := KeyGen(ikm, defaultKeyGenSalt[:], nil)
+	require.NoError(t, err)
+	require.True(t, sk.Equal(sk2))
+}
@@ -0,0 +1,100 @@
+package bls
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	popDomain = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	ErrMissingPop = errors.New("public key has no verified proof of possession")
+)
+
+// PopProve creates a proof that the holder of sk possesses the secret key
+// for its public key.
+func PopProve(sk *SecretKey) (*Signature, error) {
+	pk, err := PublicKeyFromSecretKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return coreSign(sk, PublicKeyToBytes(pk), popDomain)
+}
+
+// PopVerify checks a proof of possession created with PopProve.
+func PopVerify(pk *PublicKey, proof *Signature) (bool, error) {
+	if err := keyValidate(pk); err != nil {
+		return false, err
+	}
+	return coreVerify(proof, pk, PublicKeyToBytes(pk), popDomain)
+}
+
+func keyValidate(pk *PublicKey) error {
+	if pk.IsInfinity() {
+		return ErrPubkeyIsInfinity
+	}
+	if !pk.IsInSubGroup() {
+		return ErrPubkeyNotInSubgroup
+	}
+	return nil
+}
+
+// PopKeySet is a concurrency-safe set of public keys whose proof of
+// possession has been verified. Aggregating only keys from the set rules out
+// rogue-key attacks against FastAggregateVerify.
+type PopKeySet struct {
+	mu   sync.RWMutex
+	keys map[[PublicKeyLength]byte]struct{}
+}
+
+func NewPopKeySet() *PopKeySet {
+	return &PopKeySet{keys: make(map[[PublicKeyLength]byte]struct{})}
+}
+
+// Add verifies proof for pk and adds pk to the set if it is valid.
+func (s *PopKeySet) Add(pk *PublicKey, proof *Signature) (bool, error) {
+	ok, err := PopVerify(pk, proof)
+	if err != nil || !ok {
+		return false, err
+	}
+	s.mu.Lock()
+	s.keys[pk.Bytes()] = struct{}{}
+	s.mu.Unlock()
+	return true, nil
+}
+
+// Remove drops pk from the set.
+func (s *PopKeySet) Remove(pk *PublicKey) {
+	s.mu.Lock()
+	delete(s.keys, pk.Bytes())
+	s.mu.Unlock()
+}
+
+// Contains reports whether pk has a verified proof of possession.
+func (s *PopKeySet) Contains(pk *PublicKey) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.keys[pk.Bytes()]
+	return ok
+}
+
+// Len returns the number of keys in the set.
+func (s *PopKeySet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.keys)
+}
+
+// FastAggregateVerify is like the package-level FastAggregateVerify but
+// fails with ErrMissingPop unless every key in pks is in the set.
+func (s *PopKeySet) FastAggregateVerify(sig *Signature, pks []*PublicKey, msg []byte) (bool, error) {
+	s.mu.RLock()
+	for _, pk := range pks {
+		if _, ok := s.keys[pk.Bytes()]; !ok {
+			s.mu.RUnlock()
+			return false, ErrMissingPop
+		}
+	}
+	s.mu.RUnlock()
+	return FastAggregateVerify(sig, pks, msg)
+}
@@ -0,0 +1,71 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopProveVerify(t *testing.T) {
+	sks, pks := genKeypairs(t, 2)
+
+	proof, err := PopProve(sks[0])
+	require.NoError(t, err)
+
+	ok, err := PopVerify(pks[0], proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = PopVerify(pks[1], proof)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// A plain signature over the public key uses a different DST and must
+	// not be accepted as a proof.
+	sig := Sign(sks[0], PublicKeyToBytes(pks[0]))
+	ok, err = PopVerify(pks[0], sig)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = PopVerify(new(PublicKey), proof)
+	require.ErrorIs(t, err, ErrPubkeyIsInfinity)
+}
+
+func TestPopKeySet(t *testing.T) {
+	sks, pks := genKeypairs(t, 3)
+	set := NewPopKeySet()
+
+	msg := []byte("registration")
+	sigs := make([]*Signature, len(sks))
+	for i, sk := range sks {
+		proof, err := PopProve(sk)
+		require.NoError(t, err)
+		if i < 2 {
+			ok, err := set.Add(pks[i], proof)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+		sigs[i] = Sign(sk, msg)
+	}
+	require.Equal(t, 2, set.Len())
+	require.True(t, set.Contains(pks[0]))
+	require.False(t, set.Contains(pks[2]))
+
+	ok, err := set.Add(pks[2], sigs[2])
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	aggSig, err := AggregateSignatures(sigs[:2])
+	require.NoError(t, err)
+	ok, err = set.FastAggregateVerify(aggSig, pks[:2], msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	aggSig, err = AggregateSignatures(sigs)
+	require.NoError(t, err)
+	_, err = set.FastAggregateVerify(aggSig, pks, msg)
+	require.ErrorIs(t, err, ErrMissingPop)
+
+	set.Remove(pks[0])
+	require.False(t, set.Contains(pks[0]))
+}
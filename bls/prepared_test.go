@@ -0,0 +1,69 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparedPublicKey(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msg := []byte("message")
+	sig := Sign(sk, msg)
+
+	prepared, err := NewPreparedPublicKey(pk)
+	require.NoError(t, err)
+	require.Equal(t, PublicKeyToBytes(pk), prepared.Bytes())
+	require.True(t, pk.Equal(prepared.PublicKey()))
+
+	fromBytes, err := PreparePublicKeyBytes(PublicKeyToBytes(pk))
+	require.NoError(t, err)
+	require.Equal(t, prepared, fromBytes)
+
+	ok, err := prepared.Verify(sig, msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = prepared.VerifyBytes(msg, SignatureToBytes(sig))
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = prepared.Verify(sig, []byte("other message"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = NewPreparedPublicKey(new(PublicKey))
+	require.ErrorIs(t, err, ErrPubkeyIsInfinity)
+	_, err = PreparePublicKeyBytes(g1NotInSubgroup)
+	require.ErrorIs(t, err, ErrPubkeyNotInSubgroup)
+	notInSubgroup, err := DecodePublicKey(g1NotInSubgroup, DecodeTrusted)
+	require.NoError(t, err)
+	_, err = NewPreparedPublicKey(notInSubgroup)
+	require.ErrorIs(t, err, ErrPubkeyNotInSubgroup)
+}
+
+func BenchmarkVerifySignatureBytes(b *testing.B) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(b, err)
+	msg := []byte("message")
+	sigBytes := SignatureToBytes(Sign(sk, msg))
+	pkBytes := PublicKeyToBytes(pk)
+
+	b.Run("decoded", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ok, err := VerifySignatureBytes(msg, sigBytes, pkBytes)
+			require.NoError(b, err)
+			require.True(b, ok)
+		}
+	})
+
+	b.Run("prepared", func(b *testing.B) {
+		prepared, err := PreparePublicKeyBytes(pkBytes)
+		require.NoError(b, err)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			ok, err := prepared.VerifyBytes(msg, sigBytes)
+			require.NoError(b, err)
+			require.True(b, ok)
+		}
+	})
+}
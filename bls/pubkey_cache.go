@@ -0,0 +1,77 @@
+package bls
+
+import (
+	"sync/atomic"
+
+	"github.com/flashbots/go-boost-utils/internal/lru"
+)
+
+// PublicKeyCache maps compressed public keys to decoded and validated
+// ones, so hot keys are decompressed and subgroup-checked only once. It is
+// safe for concurrent use.
+type PublicKeyCache struct {
+	keys   *lru.Cache[[PublicKeyLength]byte, PublicKey]
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// CacheStats is a snapshot of the usage of a cache.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Len    int
+}
+
+// NewPublicKeyCache returns a cache holding at most size public keys.
+func NewPublicKeyCache(size int) *PublicKeyCache {
+	return &PublicKeyCache{keys: lru.New[[PublicKeyLength]byte, PublicKey](size, nil)}
+}
+
+// PublicKey returns the public key for pkBytes, decoding it with
+// DecodeStrict on a miss. Keys that fail to decode are not cached.
+func (c *PublicKeyCache) PublicKey(pkBytes []byte) (*PublicKey, error) {
+	if len(pkBytes) != PublicKeyLength {
+		return nil, ErrInvalidPubkeyLength
+	}
+	key := [PublicKeyLength]byte(pkBytes)
+	if pk, ok := c.keys.Get(key); ok {
+		c.hits.Add(1)
+		return &pk, nil
+	}
+	c.misses.Add(1)
+
+	pk, err := DecodePublicKey(pkBytes, DecodeStrict)
+	if err != nil {
+		return nil, err
+	}
+	c.keys.Add(key, *pk)
+	return pk, nil
+}
+
+// VerifySignatureBytes is like the package-level VerifySignatureBytes but
+// looks the public key up in the cache.
+func (c *PublicKeyCache) VerifySignatureBytes(msg, sigBytes, pkBytes []byte) (bool, error) {
+	pk, err := c.PublicKey(pkBytes)
+	if err != nil {
+		return false, err
+	}
+	sig, err := SignatureFromBytes(sigBytes)
+	if err != nil {
+		return false, err
+	}
+	return VerifySignature(sig, pk, msg)
+}
+
+// Stats returns the number of hits, misses and cached keys.
+func (c *PublicKeyCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Len:    c.keys.Len(),
+	}
+}
+
+// Purge removes all keys from the cache. The stats are kept.
+func (c *PublicKeyCache) Purge() {
+	c.keys.Purge()
+}
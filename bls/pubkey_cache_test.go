@@ -0,0 +1,67 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeyCache(t *testing.T) {
+	c := NewPublicKeyCache(2)
+	sets := genSignatureSets(t, 3)
+	pkBytes := make([][]byte, len(sets))
+	for i, set := range sets {
+		pkBytes[i] = PublicKeyToBytes(set.PublicKey)
+	}
+
+	pk, err := c.PublicKey(pkBytes[0])
+	require.NoError(t, err)
+	require.True(t, sets[0].PublicKey.Equal(pk))
+	pk, err = c.PublicKey(pkBytes[0])
+	require.NoError(t, err)
+	require.True(t, sets[0].PublicKey.Equal(pk))
+	require.Equal(t, CacheStats{Hits: 1, Misses: 1, Len: 1}, c.Stats())
+
+	// Callers cannot modify cached keys.
+	pk.X.SetOne()
+	pk, err = c.PublicKey(pkBytes[0])
+	require.NoError(t, err)
+	require.True(t, sets[0].PublicKey.Equal(pk))
+
+	for i, set := range sets {
+		ok, err := c.VerifySignatureBytes(set.Message, SignatureToBytes(set.Signature), pkBytes[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	require.Equal(t, CacheStats{Hits: 3, Misses: 3, Len: 2}, c.Stats())
+
+	_, err = c.PublicKey(g1NotInSubgroup)
+	require.ErrorIs(t, err, ErrPubkeyNotInSubgroup)
+	_, err = c.PublicKey(pkBytes[0][:10])
+	require.ErrorIs(t, err, ErrInvalidPubkeyLength)
+	require.Equal(t, 2, c.Stats().Len)
+
+	c.Purge()
+	require.Equal(t, CacheStats{Hits: 3, Misses: 4, Len: 0}, c.Stats())
+}
+
+func BenchmarkPublicKeyCache(b *testing.B) {
+	_, pk, err := GenerateNewKeypair()
+	require.NoError(b, err)
+	pkBytes := PublicKeyToBytes(pk)
+
+	b.Run("uncached", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, err := PublicKeyFromBytes(pkBytes)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		c := NewPublicKeyCache(1)
+		for n := 0; n < b.N; n++ {
+			_, err := c.PublicKey(pkBytes)
+			require.NoError(b, err)
+		}
+	})
+}
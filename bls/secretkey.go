@@ -0,0 +1,159 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const redacted = "<redacted>"
+
+var ErrMemoryLockUnsupported = errors.New("locking memory is not supported on this platform")
+
+// SecretKey is an opaque BLS secret key. Its value can only be read through
+// SecretKeyToBytes; printing or JSON-encoding it yields a redacted
+// placeholder. Copies of a SecretKey share the same underlying scalar, so
+// Zeroize wipes all of them.
+//
+// Lock and Zeroize may be called while the key is in use for signing; they
+// wait for signatures in progress, and later ones fail with
+// ErrSecretKeyIsZero. Temporary copies that the package makes of the
+// scalar are wiped after use, but copies made on the stack by the curve
+// arithmetic of the backends are not.
+//
+// The zero value is a zero key, which cannot be used for signing.
+type SecretKey struct {
+	s *scalarStorage
+}
+
+// scalarStorage holds the scalar either on the heap or, once locked, in a
+// page of memory that is excluded from swap and core dumps. mu guards both
+// against Lock and Zeroize.
+type scalarStorage struct {
+	mu      sync.RWMutex
+	heap    fr.Element
+	locked  []byte
+	cleanup runtime.Cleanup
+}
+
+func newSecretKey(e *fr.Element) *SecretKey {
+	sk := &SecretKey{s: new(scalarStorage)}
+	sk.s.heap.Set(e)
+	return sk
+}
+
+// withScalar calls f with the scalar while holding the read lock, so that
+// Lock and Zeroize wait for f to return. f must neither keep the pointer
+// nor call other methods of sk.
+func (sk *SecretKey) withScalar(f func(e *fr.Element)) {
+	if sk.s == nil {
+		f(new(fr.Element))
+		return
+	}
+	sk.s.mu.RLock()
+	defer sk.s.mu.RUnlock()
+	if sk.s.locked != nil {
+		f(lockedScalar(sk.s.locked))
+		return
+	}
+	f(&sk.s.heap)
+}
+
+// IsZero reports whether sk is the zero key, e.g. after Zeroize.
+func (sk *SecretKey) IsZero() bool {
+	var zero bool
+	sk.withScalar(func(e *fr.Element) { zero = e.IsZero() })
+	return zero
+}
+
+// Equal reports whether sk and other hold the same scalar.
+func (sk *SecretKey) Equal(other *SecretKey) bool {
+	if sk.s == other.s {
+		return true
+	}
+	var equal bool
+	sk.withScalar(func(a *fr.Element) {
+		other.withScalar(func(b *fr.Element) { equal = a.Equal(b) })
+	})
+	return equal
+}
+
+// scalarBigInt returns e as a big.Int, which the caller wipes with
+// clearBigInt.
+func scalarBigInt(e *fr.Element) *big.Int {
+	return e.BigInt(new(big.Int))
+}
+
+// clearBigInt overwrites the words of b, including unused capacity, and
+// sets it to zero.
+func clearBigInt(b *big.Int) {
+	words := b.Bits()
+	clear(words[:cap(words)])
+	b.SetInt64(0)
+}
+
+// Lock moves the scalar into a dedicated memory page that is locked into
+// RAM and excluded from core dumps. It is only supported on Linux and
+// returns ErrMemoryLockUnsupported elsewhere.
+func (sk *SecretKey) Lock() error {
+	if sk.s == nil {
+		sk.s = new(scalarStorage)
+	}
+	sk.s.mu.Lock()
+	defer sk.s.mu.Unlock()
+	if sk.s.locked != nil {
+		return nil
+	}
+	buf, err := lockMemory()
+	if err != nil {
+		return err
+	}
+	lockedScalar(buf).Set(&sk.s.heap)
+	sk.s.heap.SetZero()
+	sk.s.locked = buf
+	sk.s.cleanup = runtime.AddCleanup(sk.s, releaseMemory, buf)
+	return nil
+}
+
+// Zeroize overwrites the scalar with zeros and releases locked memory. The
+// key, and every copy of it, is unusable afterwards.
+func (sk *SecretKey) Zeroize() {
+	if sk.s == nil {
+		return
+	}
+	sk.s.mu.Lock()
+	defer sk.s.mu.Unlock()
+	sk.s.heap.SetZero()
+	if sk.s.locked != nil {
+		sk.s.cleanup.Stop()
+		releaseMemory(sk.s.locked)
+		sk.s.locked = nil
+	}
+}
+
+func (SecretKey) String() string {
+	return redacted
+}
+
+func (SecretKey) GoString() string {
+	return redacted
+}
+
+// Format makes every fmt verb, including %x, print the placeholder.
+func (SecretKey) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+func (SecretKey) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+func releaseMemory(buf []byte) {
+	clear(buf)
+	unlockMemory(buf)
+}
@@ -0,0 +1,31 @@
+package bls
+
+import (
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/sys/unix"
+)
+
+func lockMemory() ([]byte, error) {
+	buf, err := unix.Mmap(-1, 0, unix.Getpagesize(), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Mlock(buf); err != nil {
+		_ = unix.Munmap(buf)
+		return nil, err
+	}
+	// Best effort, not all kernels support it.
+	_ = unix.Madvise(buf, unix.MADV_DONTDUMP)
+	return buf, nil
+}
+
+func unlockMemory(buf []byte) {
+	_ = unix.Munlock(buf)
+	_ = unix.Munmap(buf)
+}
+
+func lockedScalar(buf []byte) *fr.Element {
+	return (*fr.Element)(unsafe.Pointer(&buf[0]))
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package bls
+
+import "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+func lockMemory() ([]byte, error) {
+	return nil, ErrMemoryLockUnsupported
+}
+
+func unlockMemory([]byte) {}
+
+func lockedScalar([]byte) *fr.Element {
+	panic("unreachable: memory locking is not supported")
+}
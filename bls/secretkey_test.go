@@ -0,0 +1,124 @@
+package bls
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretKeyRedacted(t *testing.T) {
+	skBytes := hexutil.MustDecode("0x263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")
+	sk, err := SecretKeyFromBytes(skBytes)
+	require.NoError(t, err)
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%x"} {
+		for _, arg := range []any{sk, *sk} {
+			out := fmt.Sprintf(format, arg)
+			require.Contains(t, out, redacted, format)
+			require.NotContains(t, out, "263dbd79", format)
+		}
+	}
+
+	out, err := json.Marshal(struct {
+		Key *SecretKey `json:"key"`
+	}{sk})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"key":"<redacted>"}`, string(out))
+}
+
+func TestSecretKeyZeroize(t *testing.T) {
+	sk, err := GenerateRandomSecretKey()
+	require.NoError(t, err)
+	skCopy := *sk
+
+	sk.Zeroize()
+	require.True(t, sk.IsZero())
+	require.True(t, skCopy.IsZero())
+	require.Equal(t, make([]byte, SecretKeyLength), SecretKeyToBytes(sk))
+
+	_, err = SignE(sk, []byte("message"))
+	require.ErrorIs(t, err, ErrSecretKeyIsZero)
+	require.Panics(t, func() { Sign(sk, []byte("message")) })
+	_, err = PublicKeyFromSecretKey(sk)
+	require.ErrorIs(t, err, ErrSecretKeyIsZero)
+
+	var zero SecretKey
+	require.True(t, zero.IsZero())
+	zero.Zeroize()
+}
+
+func TestSecretKeyZeroizeWhileSigning(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msg := []byte("message")
+
+	// Signers run until the key is zeroized and report the first
+	// signature that does not verify.
+	errs := make(chan error, 4)
+	for range cap(errs) {
+		go func() {
+			for {
+				sig, err := SignE(sk, msg)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if ok, err := VerifySignature(sig, pk, msg); err != nil || !ok {
+					errs <- fmt.Errorf("invalid signature: %w", err)
+					return
+				}
+			}
+		}()
+	}
+	_ = sk.Lock()
+	sk.Zeroize()
+	for range cap(errs) {
+		require.ErrorIs(t, <-errs, ErrSecretKeyIsZero)
+	}
+}
+
+func TestClearBigInt(t *testing.T) {
+	b, ok := new(big.Int).SetString("20397789859736650942317412262472558107875392172444076792671091975210932703118", 10)
+	require.True(t, ok)
+	words := b.Bits()
+	b.Rsh(b, 200)
+	clearBigInt(b)
+	require.Zero(t, b.Sign())
+	for _, w := range words[:cap(words)] {
+		require.Zero(t, w)
+	}
+}
+
+func TestSecretKeyLock(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	skBytes := SecretKeyToBytes(sk)
+
+	err = sk.Lock()
+	if runtime.GOOS != "linux" {
+		require.ErrorIs(t, err, ErrMemoryLockUnsupported)
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "cannot allocate memory") {
+		t.Skip("RLIMIT_MEMLOCK too low:", err)
+	}
+	require.NoError(t, err)
+	require.NoError(t, sk.Lock())
+	require.Equal(t, skBytes, SecretKeyToBytes(sk))
+
+	msg := []byte("message")
+	sig, err := SignE(sk, msg)
+	require.NoError(t, err)
+	ok, err := VerifySignature(sig, pk, msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	sk.Zeroize()
+	require.True(t, sk.IsZero())
+}
@@ -0,0 +1,186 @@
+package bls
+
+import (
+	"errors"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Threshold signatures with Shamir secret sharing. A key is split into n
+// shares f(1), …, f(n) of a random polynomial f of degree t-1 with
+// f(0) = sk. Any t partial signatures σᵢ = f(i)⋅H(m) recombine into
+// σ = Σ λᵢ⋅σᵢ = sk⋅H(m), where λᵢ are the Lagrange coefficients at zero.
+
+var (
+	ErrInvalidThreshold  = errors.New("threshold must be between 1 and the number of shares")
+	ErrInvalidShareIndex = errors.New("share index must not be zero")
+	ErrDuplicateShare    = errors.New("duplicate share index")
+)
+
+// SecretKeyShare is the share of a secret key held by one participant.
+// Indices start at 1.
+type SecretKeyShare struct {
+	Index     uint64
+	SecretKey *SecretKey
+}
+
+// PublicKeyShare is the public key of a SecretKeyShare.
+type PublicKeyShare struct {
+	Index     uint64
+	PublicKey *PublicKey
+}
+
+// SignatureShare is a partial signature made with a SecretKeyShare.
+type SignatureShare struct {
+	Index     uint64
+	Signature *Signature
+}
+
+// SplitSecretKey splits sk into n shares of which any threshold recover
+// signatures that verify under the public key of sk.
+func SplitSecretKey(sk *SecretKey, threshold, n int) ([]*SecretKeyShare, error) {
+	if threshold < 1 || threshold > n {
+		return nil, ErrInvalidThreshold
+	}
+	if sk.IsZero() {
+		return nil, ErrSecretKeyIsZero
+	}
+
+	coeffs := make([]fr.Element, threshold)
+	defer func() {
+		for i := range coeffs {
+			coeffs[i].SetZero()
+		}
+	}()
+	sk.withScalar(func(e *fr.Element) { coeffs[0].Set(e) })
+	for i := 1; i < threshold; i++ {
+		if _, err := coeffs[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+
+	shares := make([]*SecretKeyShare, n)
+	var x, y fr.Element
+	for i := range shares {
+		// Horner's method: f(x) = (…(a_{t-1}⋅x + a_{t-2})⋅x + …)⋅x + a_0
+		x.SetUint64(uint64(i + 1))
+		y.SetZero()
+		for j := threshold - 1; j >= 0; j-- {
+			y.Mul(&y, &x).Add(&y, &coeffs[j])
+		}
+		shares[i] = &SecretKeyShare{Index: uint64(i + 1), SecretKey: newSecretKey(&y)}
+	}
+	y.SetZero()
+	return shares, nil
+}
+
+// PublicKeyShare returns the public key of the share.
+func (s *SecretKeyShare) PublicKeyShare() (*PublicKeyShare, error) {
+	pk, err := PublicKeyFromSecretKey(s.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	return &PublicKeyShare{Index: s.Index, PublicKey: pk}, nil
+}
+
+// Sign creates a partial signature of msg.
+func (s *SecretKeyShare) Sign(msg []byte) (*SignatureShare, error) {
+	sig, err := SignE(s.SecretKey, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &SignatureShare{Index: s.Index, Signature: sig}, nil
+}
+
+// VerifySignatureShare verifies a partial signature against the public key
+// of the share that made it.
+func VerifySignatureShare(sig *SignatureShare, pk *PublicKeyShare, msg []byte) (bool, error) {
+	if sig.Index != pk.Index {
+		return false, nil
+	}
+	return VerifySignature(sig.Signature, pk.PublicKey, msg)
+}
+
+// CombineSignatureShares recovers the signature of the full key from
+// partial signatures. The result only verifies if at least threshold valid
+// shares are given.
+func CombineSignatureShares(shares []*SignatureShare) (*Signature, error) {
+	indices := make([]uint64, len(shares))
+	for i, share := range shares {
+		indices[i] = share.Index
+	}
+	lambdas, err := lagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	sigJac := new(bls12381.G2Jac)
+	lambda := new(big.Int)
+	for i, share := range shares {
+		var term bls12381.G2Jac
+		term.ScalarMultiplication(new(bls12381.G2Jac).FromAffine(share.Signature), lambdas[i].BigInt(lambda))
+		sigJac.AddAssign(&term)
+	}
+	return new(Signature).FromJacobian(sigJac), nil
+}
+
+// CombinePublicKeyShares recovers the public key of the full key from
+// threshold public key shares.
+func CombinePublicKeyShares(shares []*PublicKeyShare) (*PublicKey, error) {
+	indices := make([]uint64, len(shares))
+	for i, share := range shares {
+		indices[i] = share.Index
+	}
+	lambdas, err := lagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	pkJac := new(bls12381.G1Jac)
+	lambda := new(big.Int)
+	for i, share := range shares {
+		var term bls12381.G1Jac
+		term.ScalarMultiplication(new(bls12381.G1Jac).FromAffine(share.PublicKey), lambdas[i].BigInt(lambda))
+		pkJac.AddAssign(&term)
+	}
+	return new(PublicKey).FromJacobian(pkJac), nil
+}
+
+// lagrangeCoefficients returns λᵢ = ∏_{j≠i} xⱼ / (xⱼ - xᵢ) for
+// interpolating at zero.
+func lagrangeCoefficients(indices []uint64) ([]fr.Element, error) {
+	if len(indices) == 0 {
+		return nil, ErrEmptyAggregate
+	}
+	seen := make(map[uint64]struct{}, len(indices))
+	xs := make([]fr.Element, len(indices))
+	for i, index := range indices {
+		if index == 0 {
+			return nil, ErrInvalidShareIndex
+		}
+		if _, ok := seen[index]; ok {
+			return nil, ErrDuplicateShare
+		}
+		seen[index] = struct{}{}
+		xs[i].SetUint64(index)
+	}
+
+	lambdas := make([]fr.Element, len(indices))
+	var num, den, diff fr.Element
+	for i := range xs {
+		num.SetOne()
+		den.SetOne()
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			num.Mul(&num, &xs[j])
+			diff.Sub(&xs[j], &xs[i])
+			den.Mul(&den, &diff)
+		}
+		lambdas[i].Div(&num, &den)
+	}
+	return lambdas, nil
+}
@@ -0,0 +1,88 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdSignature(t *testing.T) {
+	sk, pk, err := GenerateNewKeypair()
+	require.NoError(t, err)
+	msg := []byte("validator registration")
+
+	shares, err := SplitSecretKey(sk, 3, 5)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	pkShares := make([]*PublicKeyShare, len(shares))
+	sigShares := make([]*SignatureShare, len(shares))
+	for i, share := range shares {
+		require.Equal(t, uint64(i+1), share.Index)
+		pkShares[i], err = share.PublicKeyShare()
+		require.NoError(t, err)
+		sigShares[i], err = share.Sign(msg)
+		require.NoError(t, err)
+
+		ok, err := VerifySignatureShare(sigShares[i], pkShares[i], msg)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	// Every subset of three shares recovers the same signature.
+	expected := Sign(sk, msg)
+	for a := 0; a < 5; a++ {
+		for b := a + 1; b < 5; b++ {
+			for c := b + 1; c < 5; c++ {
+				sig, err := CombineSignatureShares([]*SignatureShare{sigShares[c], sigShares[a], sigShares[b]})
+				require.NoError(t, err)
+				require.True(t, expected.Equal(sig))
+
+				recovered, err := CombinePublicKeyShares([]*PublicKeyShare{pkShares[a], pkShares[b], pkShares[c]})
+				require.NoError(t, err)
+				require.True(t, pk.Equal(recovered))
+			}
+		}
+	}
+
+	sig, err := CombineSignatureShares(sigShares)
+	require.NoError(t, err)
+	ok, err := VerifySignature(sig, pk, msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Two shares are not enough.
+	sig, err = CombineSignatureShares(sigShares[:2])
+	require.NoError(t, err)
+	ok, err = VerifySignature(sig, pk, msg)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = VerifySignatureShare(sigShares[0], pkShares[1], msg)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestThresholdErrors(t *testing.T) {
+	sk, _, err := GenerateNewKeypair()
+	require.NoError(t, err)
+
+	_, err = SplitSecretKey(sk, 0, 3)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+	_, err = SplitSecretKey(sk, 4, 3)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+	_, err = SplitSecretKey(new(SecretKey), 2, 3)
+	require.ErrorIs(t, err, ErrSecretKeyIsZero)
+
+	shares, err := SplitSecretKey(sk, 1, 1)
+	require.NoError(t, err)
+	require.True(t, sk.Equal(shares[0].SecretKey))
+
+	sig := Sign(sk, []byte("message"))
+	_, err = CombineSignatureShares(nil)
+	require.ErrorIs(t, err, ErrEmptyAggregate)
+	_, err = CombineSignatureShares([]*SignatureShare{{Index: 0, Signature: sig}})
+	require.ErrorIs(t, err, ErrInvalidShareIndex)
+	_, err = CombineSignatureShares([]*SignatureShare{{Index: 1, Signature: sig}, {Index: 1, Signature: sig}})
+	require.ErrorIs(t, err, ErrDuplicateShare)
+}
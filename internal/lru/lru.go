@@ -0,0 +1,113 @@
+// Package lru implements a bounded, concurrency-safe least-recently-used
+// cache.
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Cache is a least-recently-used cache holding at most size entries.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	items   map[K]*list.Element
+	order   *list.List
+	onEvict func(K, V)
+}
+
+// New returns a cache holding at most size entries. If onEvict is not nil,
+// it is called with every entry that is evicted to make room for a new one.
+// It is called without holding the cache's lock.
+func New[K comparable, V any](size int, onEvict func(K, V)) *Cache[K, V] {
+	if size < 1 {
+		size = 1
+	}
+	return &Cache[K, V]{
+		size:    size,
+		items:   make(map[K]*list.Element),
+		order:   list.New(),
+		onEvict: onEvict,
+	}
+}
+
+// Get returns the value stored for key and marks it as recently used.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*entry[K, V]).value, true
+}
+
+// Contains reports whether key is in the cache without marking it as
+// recently used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[key]
+	return ok
+}
+
+// Add stores value for key, evicting the least recently used entry if the
+// cache is full. It reports whether an entry was evicted.
+func (c *Cache[K, V]) Add(key K, value V) (evicted bool) {
+	c.mu.Lock()
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return false
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+	var oldest *entry[K, V]
+	if c.order.Len() > c.size {
+		oldest = c.order.Remove(c.order.Back()).(*entry[K, V])
+		delete(c.items, oldest.key)
+	}
+	c.mu.Unlock()
+
+	if oldest == nil {
+		return false
+	}
+	if c.onEvict != nil {
+		c.onEvict(oldest.key, oldest.value)
+	}
+	return true
+}
+
+// Remove deletes key from the cache and reports whether it was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(elem)
+	delete(c.items, key)
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Purge removes all entries from the cache.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.items)
+	c.order.Init()
+}
@@ -0,0 +1,57 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	var evicted []int
+	c := New[int, string](2, func(k int, _ string) { evicted = append(evicted, k) })
+
+	require.False(t, c.Add(1, "one"))
+	require.False(t, c.Add(2, "two"))
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "one", v)
+
+	// 2 is now the least recently used entry.
+	require.True(t, c.Add(3, "three"))
+	require.Equal(t, []int{2}, evicted)
+	require.False(t, c.Contains(2))
+	require.True(t, c.Contains(1))
+	require.Equal(t, 2, c.Len())
+
+	require.False(t, c.Add(3, "drei"))
+	v, ok = c.Get(3)
+	require.True(t, ok)
+	require.Equal(t, "drei", v)
+
+	require.True(t, c.Remove(3))
+	require.False(t, c.Remove(3))
+	_, ok = c.Get(3)
+	require.False(t, ok)
+
+	c.Purge()
+	require.Equal(t, 0, c.Len())
+	require.Equal(t, []int{2}, evicted)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := New[int, int](64, nil)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Add(i%128, i)
+				c.Get(i % 97)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 64, c.Len())
+}
@@ -0,0 +1,371 @@
+// Package keymanager loads validator keystores from a directory in the
+// layout of a consensus client and keeps them up to date by polling.
+package keymanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	builderApiV1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/keystore"
+	"github.com/flashbots/go-boost-utils/signer"
+	"github.com/flashbots/go-boost-utils/ssz"
+)
+
+const DefaultPollInterval = 10 * time.Second
+
+var (
+	ErrUnknownLayout   = errors.New("unknown keystore layout")
+	ErrMissingPassword = errors.New("layout needs a secrets directory or password file")
+)
+
+// Layout is the way a consensus client arranges keystores and passwords.
+type Layout string
+
+const (
+	// LayoutLighthouse has a directory per key named after its public key,
+	// holding voting-keystore.json. The password is in the file of the
+	// same name in the secrets directory.
+	LayoutLighthouse Layout = "lighthouse"
+	// LayoutSharedPassword has keystore files, as created by the deposit
+	// CLI, that share the password in a single file. Prysm wallets, which
+	// keep all keys in one encrypted file, are not supported.
+	LayoutSharedPassword Layout = "shared-password"
+	// LayoutTeku has keystore files whose passwords are in files of the
+	// same name with the extension .txt in the secrets directory.
+	LayoutTeku Layout = "teku"
+)
+
+// EventType says whether a key was added or removed.
+type EventType int
+
+const (
+	KeyAdded EventType = iota
+	KeyRemoved
+)
+
+func (t EventType) String() string {
+	switch t {
+	case KeyAdded:
+		return "added"
+	case KeyRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event reports a key that was added or removed.
+type Event struct {
+	Type      EventType
+	PublicKey phase0.BLSPubKey
+}
+
+type Config struct {
+	Layout Layout
+	// KeysDir holds the keystores.
+	KeysDir string
+	// SecretsDir holds the password files for LayoutLighthouse and
+	// LayoutTeku.
+	SecretsDir string
+	// PasswordFile holds the password for LayoutSharedPassword.
+	PasswordFile string
+	// PollInterval is how often Run looks for changes, DefaultPollInterval
+	// if zero.
+	PollInterval time.Duration
+	// OnEvent, if set, is called for every key added or removed by a
+	// reload, from the goroutine doing the reload.
+	OnEvent func(Event)
+}
+
+// Manager holds the keys found in a directory.
+type Manager struct {
+	cfg Config
+
+	// reloadMu serializes reloads, which own files.
+	reloadMu sync.Mutex
+	files    map[string]*file
+
+	mu      sync.RWMutex
+	signers map[phase0.BLSPubKey]signer.Signer
+}
+
+// file is a keystore and the state of its files when it was loaded.
+type file struct {
+	keystore, password fileState
+	signer             *signer.LocalSigner
+	err                error
+}
+
+type fileState struct {
+	modTime int64
+	size    int64
+}
+
+// candidate is a keystore file and the file with its password.
+type candidate struct {
+	keystore, password string
+}
+
+// New loads the keys of cfg. Keystores that fail to load are skipped and
+// reported in the returned error, along with the Manager.
+func New(cfg Config) (*Manager, error) {
+	switch cfg.Layout {
+	case LayoutLighthouse, LayoutTeku:
+		if cfg.SecretsDir == "" {
+			return nil, ErrMissingPassword
+		}
+	case LayoutSharedPassword:
+		if cfg.PasswordFile == "" {
+			return nil, ErrMissingPassword
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownLayout, cfg.Layout)
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	m := &Manager{
+		cfg:     cfg,
+		files:   make(map[string]*file),
+		signers: make(map[phase0.BLSPubKey]signer.Signer),
+	}
+	return m, m.Reload()
+}
+
+// Run reloads the keys every poll interval until ctx is done. Errors of
+// individual reloads are passed to onError, if set.
+func (m *Manager) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := m.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Reload rescans the directory. Keystores are only decrypted again if they
+// or their password changed. A keystore that fails to load is skipped, and
+// its key removed, until it is fixed. If the directory cannot be read at
+// all, the keys are kept.
+//
+// A keystore that is loaded again keeps its signer if it still holds the
+// same key. The secret keys that leave the set are zeroized, so signers of
+// them returned by Signer before the reload stop working.
+func (m *Manager) Reload() error {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	candidates, err := m.candidates()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	files := make(map[string]*file, len(candidates))
+	for _, c := range candidates {
+		f := m.load(c)
+		if f.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.keystore, f.err))
+		}
+		files[c.keystore] = f
+	}
+	prevFiles := m.files
+	m.files = files
+
+	// With several keystores of the same key, the first path wins.
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	signers := make(map[phase0.BLSPubKey]signer.Signer, len(files))
+	for _, path := range paths {
+		if s := files[path].signer; s != nil {
+			if _, ok := signers[s.PublicKey()]; !ok {
+				signers[s.PublicKey()] = s
+			}
+		}
+	}
+
+	m.mu.Lock()
+	old := m.signers
+	m.signers = signers
+	m.mu.Unlock()
+
+	for _, f := range prevFiles {
+		if f.signer == nil {
+			continue
+		}
+		if _, ok := signers[f.signer.PublicKey()]; !ok {
+			f.signer.Zeroize()
+		}
+	}
+
+	if m.cfg.OnEvent != nil {
+		for _, pk := range sortedKeys(old) {
+			if _, ok := signers[pk]; !ok {
+				m.cfg.OnEvent(Event{Type: KeyRemoved, PublicKey: pk})
+			}
+		}
+		for _, pk := range sortedKeys(signers) {
+			if _, ok := old[pk]; !ok {
+				m.cfg.OnEvent(Event{Type: KeyAdded, PublicKey: pk})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// load loads the keystore of c unless it is unchanged since the last
+// reload.
+func (m *Manager) load(c candidate) *file {
+	f := new(file)
+	if f.keystore, f.err = stat(c.keystore); f.err != nil {
+		return f
+	}
+	if f.password, f.err = stat(c.password); f.err != nil {
+		return f
+	}
+	if prev, ok := m.files[c.keystore]; ok && prev.keystore == f.keystore && prev.password == f.password {
+		return prev
+	}
+
+	password, err := os.ReadFile(c.password)
+	if err != nil {
+		f.err = err
+		return f
+	}
+	key, err := keystore.Load(c.keystore, strings.TrimRight(string(password), "\r\n"))
+	if err != nil {
+		f.err = err
+		return f
+	}
+	if f.signer, f.err = signer.NewLocalSigner(key.SecretKey); f.err != nil {
+		return f
+	}
+	// Keep handing out the signer of an unchanged key, e.g. after the file
+	// was rewritten, and drop the new copy.
+	if prev, ok := m.files[c.keystore]; ok && prev.signer != nil && prev.signer.PublicKey() == f.signer.PublicKey() {
+		f.signer.Zeroize()
+		f.signer = prev.signer
+	}
+	return f
+}
+
+// candidates lists the keystores in the directory with their password
+// files.
+func (m *Manager) candidates() ([]candidate, error) {
+	if _, err := os.Stat(m.cfg.KeysDir); err != nil {
+		return nil, err
+	}
+	var candidates []candidate
+	switch m.cfg.Layout {
+	case LayoutLighthouse:
+		dirs, err := filepath.Glob(filepath.Join(m.cfg.KeysDir, "0x*"))
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			ks := filepath.Join(dir, "voting-keystore.json")
+			if _, err := os.Stat(ks); err != nil {
+				continue
+			}
+			candidates = append(candidates, candidate{keystore: ks, password: filepath.Join(m.cfg.SecretsDir, filepath.Base(dir))})
+		}
+	case LayoutSharedPassword, LayoutTeku:
+		files, err := filepath.Glob(filepath.Join(m.cfg.KeysDir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, ks := range files {
+			password := m.cfg.PasswordFile
+			if m.cfg.Layout == LayoutTeku {
+				password = filepath.Join(m.cfg.SecretsDir, strings.TrimSuffix(filepath.Base(ks), ".json")+".txt")
+			}
+			candidates = append(candidates, candidate{keystore: ks, password: password})
+		}
+	}
+	return candidates, nil
+}
+
+// PublicKeys returns the public keys of all keys in ascending order.
+func (m *Manager) PublicKeys() []phase0.BLSPubKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedKeys(m.signers)
+}
+
+// Signer returns the signer for pk, if the manager holds it.
+func (m *Manager) Signer(pk phase0.BLSPubKey) (signer.Signer, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.signers[pk]
+	return s, ok
+}
+
+// SignValidatorRegistrations signs a registration with the given fee
+// recipient, gas limit and timestamp for every key, under the domain d,
+// which is normally ssz.DomainBuilder.
+func (m *Manager) SignValidatorRegistrations(ctx context.Context, feeRecipient bellatrix.ExecutionAddress, gasLimit uint64, timestamp time.Time, d phase0.Domain) ([]*builderApiV1.SignedValidatorRegistration, error) {
+	pks := m.PublicKeys()
+	regs := make([]*builderApiV1.SignedValidatorRegistration, 0, len(pks))
+	for _, pk := range pks {
+		s, ok := m.Signer(pk)
+		if !ok {
+			// Removed by a concurrent reload.
+			continue
+		}
+		msg := &builderApiV1.ValidatorRegistration{
+			FeeRecipient: feeRecipient,
+			GasLimit:     gasLimit,
+			Timestamp:    timestamp,
+			Pubkey:       pk,
+		}
+		sig, err := ssz.SignMessageWithSigner(ctx, msg, d, s)
+		if errors.Is(err, bls.ErrSecretKeyIsZero) {
+			// Zeroized by a concurrent reload.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, &builderApiV1.SignedValidatorRegistration{Message: msg, Signature: sig})
+	}
+	return regs, nil
+}
+
+func stat(name string) (fileState, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{modTime: fi.ModTime().UnixNano(), size: fi.Size()}, nil
+}
+
+func sortedKeys(signers map[phase0.BLSPubKey]signer.Signer) []phase0.BLSPubKey {
+	pks := make([]phase0.BLSPubKey, 0, len(signers))
+	for pk := range signers {
+		pks = append(pks, pk)
+	}
+	slices.SortFunc(pks, func(a, b phase0.BLSPubKey) int {
+		return strings.Compare(string(a[:]), string(b[:]))
+	})
+	return pks
+}
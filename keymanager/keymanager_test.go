@@ -0,0 +1,235 @@
+package keymanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/keystore"
+	"github.com/flashbots/go-boost-utils/signer"
+	"github.com/flashbots/go-boost-utils/signer/web3signer"
+	"github.com/flashbots/go-boost-utils/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+var _ web3signer.Keys = (*Manager)(nil)
+
+const testPassword = "hunter2"
+
+// writeKey writes a keystore for a new key into the layout of cfg and
+// returns the public key and keystore file.
+func writeKey(t *testing.T, cfg Config) (phase0.BLSPubKey, string) {
+	t.Helper()
+	sk, pk, err := bls.GenerateNewKeypair()
+	require.NoError(t, err)
+	ks, err := keystore.Encrypt(sk, testPassword, "", keystore.KDFPBKDF2)
+	require.NoError(t, err)
+	pkBytes := phase0.BLSPubKey(bls.PublicKeyToBytes(pk))
+	name := hexutil.Encode(pkBytes[:])
+
+	var file string
+	switch cfg.Layout {
+	case LayoutLighthouse:
+		require.NoError(t, os.Mkdir(filepath.Join(cfg.KeysDir, name), 0o700))
+		file = filepath.Join(cfg.KeysDir, name, "voting-keystore.json")
+		require.NoError(t, os.WriteFile(filepath.Join(cfg.SecretsDir, name), []byte(testPassword), 0o600))
+	case LayoutSharedPassword:
+		file = filepath.Join(cfg.KeysDir, "keystore-"+name+".json")
+	case LayoutTeku:
+		file = filepath.Join(cfg.KeysDir, name+".json")
+		require.NoError(t, os.WriteFile(filepath.Join(cfg.SecretsDir, name+".txt"), []byte(testPassword+"\n"), 0o600))
+	}
+	require.NoError(t, ks.WriteFile(file))
+	return pkBytes, file
+}
+
+func testConfig(t *testing.T, layout Layout) Config {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := Config{Layout: layout, KeysDir: filepath.Join(dir, "keys")}
+	require.NoError(t, os.Mkdir(cfg.KeysDir, 0o700))
+	switch layout {
+	case LayoutLighthouse, LayoutTeku:
+		cfg.SecretsDir = filepath.Join(dir, "secrets")
+		require.NoError(t, os.Mkdir(cfg.SecretsDir, 0o700))
+	case LayoutSharedPassword:
+		cfg.PasswordFile = filepath.Join(dir, "password.txt")
+		require.NoError(t, os.WriteFile(cfg.PasswordFile, []byte(testPassword+"\r\n"), 0o600))
+	}
+	return cfg
+}
+
+func TestLayouts(t *testing.T) {
+	for _, layout := range []Layout{LayoutLighthouse, LayoutSharedPassword, LayoutTeku} {
+		t.Run(string(layout), func(t *testing.T) {
+			cfg := testConfig(t, layout)
+			pk1, _ := writeKey(t, cfg)
+			pk2, _ := writeKey(t, cfg)
+			// Other files are ignored.
+			require.NoError(t, os.WriteFile(filepath.Join(cfg.KeysDir, "README"), nil, 0o600))
+
+			m, err := New(cfg)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []phase0.BLSPubKey{pk1, pk2}, m.PublicKeys())
+			for _, pk := range []phase0.BLSPubKey{pk1, pk2} {
+				s, ok := m.Signer(pk)
+				require.True(t, ok)
+				require.Equal(t, pk, s.PublicKey())
+			}
+			_, ok := m.Signer(phase0.BLSPubKey{})
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	cfg := testConfig(t, LayoutTeku)
+	var events []Event
+	cfg.OnEvent = func(e Event) { events = append(events, e) }
+	pk1, file1 := writeKey(t, cfg)
+
+	m, err := New(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []Event{{Type: KeyAdded, PublicKey: pk1}}, events)
+	s1, _ := m.Signer(pk1)
+
+	// Unchanged keystores are not loaded again.
+	events = nil
+	pk2, _ := writeKey(t, cfg)
+	require.NoError(t, m.Reload())
+	require.Equal(t, []Event{{Type: KeyAdded, PublicKey: pk2}}, events)
+	s, _ := m.Signer(pk1)
+	require.Same(t, s1, s)
+
+	events = nil
+	require.NoError(t, os.Remove(file1))
+	require.NoError(t, m.Reload())
+	require.Equal(t, []Event{{Type: KeyRemoved, PublicKey: pk1}}, events)
+	require.Equal(t, []phase0.BLSPubKey{pk2}, m.PublicKeys())
+	// The key of the removed keystore is wiped.
+	_, err = s1.SignRoot(context.Background(), phase0.Root{})
+	require.ErrorIs(t, err, bls.ErrSecretKeyIsZero)
+	s2, _ := m.Signer(pk2)
+
+	// A keystore with a wrong password is reported and skipped.
+	events = nil
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.SecretsDir, hexutil.Encode(pk2[:])+".txt"), []byte("hunter3"), 0o600))
+	require.ErrorIs(t, m.Reload(), keystore.ErrInvalidPassword)
+	require.Equal(t, []Event{{Type: KeyRemoved, PublicKey: pk2}}, events)
+	require.Empty(t, m.PublicKeys())
+	// So is the key of a keystore that no longer loads.
+	_, err = s2.SignRoot(context.Background(), phase0.Root{})
+	require.ErrorIs(t, err, bls.ErrSecretKeyIsZero)
+
+	// The keys are kept while the directory is unreadable.
+	events = nil
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.SecretsDir, hexutil.Encode(pk2[:])+".txt"), []byte(testPassword), 0o600))
+	require.NoError(t, m.Reload())
+	require.NoError(t, os.Rename(cfg.KeysDir, cfg.KeysDir+".old"))
+	require.Error(t, m.Reload())
+	require.Equal(t, []phase0.BLSPubKey{pk2}, m.PublicKeys())
+	require.Equal(t, []Event{{Type: KeyAdded, PublicKey: pk2}}, events)
+}
+
+func TestReloadRewrittenKeystore(t *testing.T) {
+	cfg := testConfig(t, LayoutTeku)
+	pk, file := writeKey(t, cfg)
+	m, err := New(cfg)
+	require.NoError(t, err)
+	s, ok := m.Signer(pk)
+	require.True(t, ok)
+
+	// A keystore that is rewritten unchanged keeps its signer, and signers
+	// handed out before keep working.
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(file, later, later))
+	require.NoError(t, m.Reload())
+	_, err = s.SignRoot(context.Background(), phase0.Root{})
+	require.NoError(t, err)
+	s2, _ := m.Signer(pk)
+	require.Same(t, s, s2)
+
+	// A keystore replaced by one of another key wipes the old key.
+	sk, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	ks, err := keystore.Encrypt(sk, testPassword, "", keystore.KDFPBKDF2)
+	require.NoError(t, err)
+	require.NoError(t, ks.WriteFile(file))
+	require.NoError(t, m.Reload())
+	_, ok = m.Signer(pk)
+	require.False(t, ok)
+	_, err = s.SignRoot(context.Background(), phase0.Root{})
+	require.ErrorIs(t, err, bls.ErrSecretKeyIsZero)
+}
+
+func TestRun(t *testing.T) {
+	cfg := testConfig(t, LayoutSharedPassword)
+	cfg.PollInterval = 10 * time.Millisecond
+	added := make(chan phase0.BLSPubKey, 1)
+	cfg.OnEvent = func(e Event) {
+		if e.Type == KeyAdded {
+			added <- e.PublicKey
+		}
+	}
+	m, err := New(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx, nil) }()
+
+	pk, _ := writeKey(t, cfg)
+	select {
+	case got := <-added:
+		require.Equal(t, pk, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("key not added")
+	}
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestSignValidatorRegistrations(t *testing.T) {
+	cfg := testConfig(t, LayoutLighthouse)
+	writeKey(t, cfg)
+	writeKey(t, cfg)
+	m, err := New(cfg)
+	require.NoError(t, err)
+
+	timestamp := time.Unix(1_700_000_000, 0)
+	regs, err := m.SignValidatorRegistrations(context.Background(), bellatrix.ExecutionAddress{0x42}, 30_000_000, timestamp, ssz.DomainBuilder)
+	require.NoError(t, err)
+	require.Len(t, regs, 2)
+	for i, reg := range regs {
+		require.Equal(t, m.PublicKeys()[i], reg.Message.Pubkey)
+		require.Equal(t, uint64(30_000_000), reg.Message.GasLimit)
+		ok, err := ssz.VerifySignature(reg.Message, ssz.DomainBuilder, reg.Message.Pubkey[:], reg.Signature[:])
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	// A key zeroized by a concurrent reload is skipped.
+	s, _ := m.Signer(m.PublicKeys()[0])
+	s.(*signer.LocalSigner).Zeroize()
+	regs, err = m.SignValidatorRegistrations(context.Background(), bellatrix.ExecutionAddress{0x42}, 30_000_000, timestamp, ssz.DomainBuilder)
+	require.NoError(t, err)
+	require.Len(t, regs, 1)
+	require.Equal(t, m.PublicKeys()[1], regs[0].Message.Pubkey)
+}
+
+func TestConfigErrors(t *testing.T) {
+	_, err := New(Config{Layout: "nimbus", KeysDir: t.TempDir()})
+	require.ErrorIs(t, err, ErrUnknownLayout)
+	_, err = New(Config{Layout: LayoutTeku, KeysDir: t.TempDir()})
+	require.ErrorIs(t, err, ErrMissingPassword)
+	_, err = New(Config{Layout: LayoutSharedPassword, KeysDir: t.TempDir()})
+	require.ErrorIs(t, err, ErrMissingPassword)
+	_, err = New(Config{Layout: LayoutSharedPassword, KeysDir: filepath.Join(t.TempDir(), "missing"), PasswordFile: "password.txt"})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
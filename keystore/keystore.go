@@ -0,0 +1,363 @@
+// Package keystore implements the EIP-2335 BLS12-381 keystore format:
+// https://eips.ethereum.org/EIPS/eip-2335
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flashbots/go-boost-utils/bls"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	Version = 4
+
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	ChecksumSHA256 = "sha256"
+	CipherAES128   = "aes-128-ctr"
+	PRFHMACSHA256  = "hmac-sha256"
+
+	// Parameters recommended by EIP-2335.
+	DefaultScryptN = 262144
+	DefaultScryptR = 8
+	DefaultScryptP = 1
+	DefaultPBKDF2C = 262144
+
+	// Upper bounds on the KDF parameters accepted by Decrypt, so that a
+	// malformed keystore cannot exhaust memory or CPU. MaxScryptMemory
+	// bounds the 128*n*r bytes scrypt allocates.
+	MaxScryptMemory = 1 << 30
+	MaxScryptP      = 16
+	MaxPBKDF2C      = 1 << 24
+
+	dkLen    = 32
+	maxDKLen = 64
+	saltLen  = 32
+)
+
+var (
+	ErrUnsupportedVersion  = errors.New("unsupported keystore version")
+	ErrUnsupportedKDF      = errors.New("unsupported key derivation function")
+	ErrUnsupportedCipher   = errors.New("unsupported cipher")
+	ErrUnsupportedChecksum = errors.New("unsupported checksum function")
+	ErrInvalidPassword     = errors.New("invalid password: checksum mismatch")
+	ErrPubkeyMismatch      = errors.New("keystore public key does not match secret key")
+)
+
+// Keystore is the JSON representation of an EIP-2335 keystore.
+type Keystore struct {
+	Crypto      Crypto `json:"crypto"`
+	Description string `json:"description,omitempty"`
+	Pubkey      string `json:"pubkey"`
+	Path        string `json:"path"`
+	UUID        string `json:"uuid"`
+	Version     int    `json:"version"`
+}
+
+type Crypto struct {
+	KDF      KDFModule      `json:"kdf"`
+	Checksum ChecksumModule `json:"checksum"`
+	Cipher   CipherModule   `json:"cipher"`
+}
+
+type KDFModule struct {
+	Function string    `json:"function"`
+	Params   KDFParams `json:"params"`
+	Message  string    `json:"message"`
+}
+
+// KDFParams holds the parameters of both supported KDFs. N, R and P are
+// used by scrypt, C and PRF by pbkdf2.
+type KDFParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n,omitempty"`
+	R     int    `json:"r,omitempty"`
+	P     int    `json:"p,omitempty"`
+	C     int    `json:"c,omitempty"`
+	PRF   string `json:"prf,omitempty"`
+	Salt  string `json:"salt"`
+}
+
+type ChecksumModule struct {
+	Function string   `json:"function"`
+	Params   struct{} `json:"params"`
+	Message  string   `json:"message"`
+}
+
+type CipherModule struct {
+	Function string       `json:"function"`
+	Params   CipherParams `json:"params"`
+	Message  string       `json:"message"`
+}
+
+type CipherParams struct {
+	IV string `json:"iv"`
+}
+
+// Key is a decrypted keystore.
+type Key struct {
+	SecretKey *bls.SecretKey
+	PublicKey *bls.PublicKey
+	Path      string
+	UUID      string
+}
+
+// Encrypt protects sk with password in a new keystore, using kdf (KDFScrypt
+// or KDFPBKDF2) with the parameters recommended by EIP-2335. path is the
+// EIP-2334 derivation path of sk, or empty if it was not derived.
+func Encrypt(sk *bls.SecretKey, password, path, kdf string) (*Keystore, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := KDFParams{DKLen: dkLen, Salt: hex.EncodeToString(salt)}
+	switch kdf {
+	case KDFScrypt:
+		params.N, params.R, params.P = DefaultScryptN, DefaultScryptR, DefaultScryptP
+	case KDFPBKDF2:
+		params.C, params.PRF = DefaultPBKDF2C, PRFHMACSHA256
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKDF, kdf)
+	}
+	return encrypt(sk, password, path, KDFModule{Function: kdf, Params: params})
+}
+
+func encrypt(sk *bls.SecretKey, password, path string, kdf KDFModule) (*Keystore, error) {
+	pk, err := bls.PublicKeyFromSecretKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	decryptionKey, err := deriveKey(&kdf, password)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(decryptionKey)
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	skBytes := bls.SecretKeyToBytes(sk)
+	cipherText, err := aes128CTR(decryptionKey[:16], iv, skBytes)
+	clear(skBytes)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keystore{
+		Crypto: Crypto{
+			KDF: kdf,
+			Checksum: ChecksumModule{
+				Function: ChecksumSHA256,
+				Message:  hex.EncodeToString(checksum(decryptionKey, cipherText)),
+			},
+			Cipher: CipherModule{
+				Function: CipherAES128,
+				Params:   CipherParams{IV: hex.EncodeToString(iv)},
+				Message:  hex.EncodeToString(cipherText),
+			},
+		},
+		Pubkey:  hex.EncodeToString(bls.PublicKeyToBytes(pk)),
+		Path:    path,
+		UUID:    id,
+		Version: Version,
+	}, nil
+}
+
+// Decrypt recovers the secret key from ks. A wrong password is reported as
+// ErrInvalidPassword.
+func (ks *Keystore) Decrypt(password string) (*Key, error) {
+	if ks.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, ks.Version)
+	}
+	if ks.Crypto.Checksum.Function != ChecksumSHA256 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChecksum, ks.Crypto.Checksum.Function)
+	}
+	if ks.Crypto.Cipher.Function != CipherAES128 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCipher, ks.Crypto.Cipher.Function)
+	}
+
+	decryptionKey, err := deriveKey(&ks.Crypto.KDF, password)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(decryptionKey)
+	cipherText, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher message: %w", err)
+	}
+	expectedChecksum, err := hex.DecodeString(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum message: %w", err)
+	}
+	if !bytes.Equal(checksum(decryptionKey, cipherText), expectedChecksum) {
+		return nil, ErrInvalidPassword
+	}
+
+	iv, err := hex.DecodeString(ks.Crypto.Cipher.Params.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher iv: %w", err)
+	}
+	skBytes, err := aes128CTR(decryptionKey[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+	sk, err := bls.SecretKeyFromBytes(skBytes)
+	clear(skBytes)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := bls.PublicKeyFromSecretKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	if ks.Pubkey != "" && strings.TrimPrefix(ks.Pubkey, "0x") != hex.EncodeToString(bls.PublicKeyToBytes(pk)) {
+		return nil, ErrPubkeyMismatch
+	}
+
+	return &Key{SecretKey: sk, PublicKey: pk, Path: ks.Path, UUID: ks.UUID}, nil
+}
+
+// Load reads the keystore at file and decrypts it with password.
+func Load(file, password string) (*Key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ks := new(Keystore)
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, err
+	}
+	return ks.Decrypt(password)
+}
+
+// LoadDir loads every keystore in dir, that is every file ending in .json,
+// and decrypts it with password.
+func LoadDir(dir, password string) ([]*Key, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*Key, 0, len(files))
+	for _, file := range files {
+		key, err := Load(file, password)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Save encrypts sk with password using scrypt and writes the keystore to
+// file, readable only by the current user.
+func Save(file string, sk *bls.SecretKey, password, path string) (*Keystore, error) {
+	ks, err := Encrypt(sk, password, path, KDFScrypt)
+	if err != nil {
+		return nil, err
+	}
+	return ks, ks.WriteFile(file)
+}
+
+// WriteFile writes ks to file, readable only by the current user.
+func (ks *Keystore) WriteFile(file string) error {
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o600)
+}
+
+func deriveKey(kdf *KDFModule, password string) ([]byte, error) {
+	salt, err := hex.DecodeString(kdf.Params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kdf salt: %w", err)
+	}
+	if kdf.Params.DKLen < dkLen || kdf.Params.DKLen > maxDKLen {
+		return nil, fmt.Errorf("%w: dklen %d", ErrUnsupportedKDF, kdf.Params.DKLen)
+	}
+	pw := normalizePassword(password)
+	defer clear(pw)
+
+	switch kdf.Function {
+	case KDFScrypt:
+		n, r, p := kdf.Params.N, kdf.Params.R, kdf.Params.P
+		if r <= 0 || p <= 0 || p > MaxScryptP || n > MaxScryptMemory/128/r {
+			return nil, fmt.Errorf("%w: scrypt n %d r %d p %d", ErrUnsupportedKDF, n, r, p)
+		}
+		return scrypt.Key(pw, salt, n, r, p, kdf.Params.DKLen)
+	case KDFPBKDF2:
+		if kdf.Params.PRF != PRFHMACSHA256 {
+			return nil, fmt.Errorf("%w: prf %s", ErrUnsupportedKDF, kdf.Params.PRF)
+		}
+		if kdf.Params.C <= 0 || kdf.Params.C > MaxPBKDF2C {
+			return nil, fmt.Errorf("%w: pbkdf2 c %d", ErrUnsupportedKDF, kdf.Params.C)
+		}
+		return pbkdf2.Key(sha256.New, string(pw), salt, kdf.Params.C, kdf.Params.DKLen)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKDF, kdf.Function)
+	}
+}
+
+// normalizePassword applies NFKD and strips the C0, C1 and Delete control
+// codes as required by EIP-2335.
+func normalizePassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+	pw := make([]byte, 0, len(normalized))
+	for _, r := range normalized {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+		pw = append(pw, string(r)...)
+	}
+	return pw
+}
+
+func checksum(decryptionKey, cipherText []byte) []byte {
+	h := sha256.New()
+	h.Write(decryptionKey[16:32])
+	h.Write(cipherText)
+	return h.Sum(nil)
+}
+
+func aes128CTR(key, iv, src []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("%w: iv length %d", ErrUnsupportedCipher, len(iv))
+	}
+	dst := make([]byte, len(src))
+	cipher.NewCTR(block, iv).XORKeyStream(dst, src)
+	return dst, nil
+}
+
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
@@ -0,0 +1,151 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/stretchr/testify/require"
+)
+
+// Password and secret of the EIP-2335 test vectors. The password normalizes
+// to "testpassword🔑".
+const (
+	testPassword = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+	testSecret   = "0x000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+)
+
+func TestLoadTestVectors(t *testing.T) {
+	for _, tc := range []struct {
+		File string
+		Path string
+	}{
+		{File: "scrypt.json", Path: "m/12381/60/3141592653/589793238"},
+		{File: "pbkdf2.json", Path: "m/12381/60/0/0"},
+	} {
+		t.Run(tc.File, func(t *testing.T) {
+			key, err := Load(filepath.Join("../testdata/keystore", tc.File), testPassword)
+			require.NoError(t, err)
+			require.Equal(t, testSecret, hexutil.Encode(bls.SecretKeyToBytes(key.SecretKey)))
+			require.Equal(t, "0x9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07", hexutil.Encode(bls.PublicKeyToBytes(key.PublicKey)))
+			require.Equal(t, tc.Path, key.Path)
+
+			_, err = Load(filepath.Join("../testdata/keystore", tc.File), "testpassword")
+			require.ErrorIs(t, err, ErrInvalidPassword)
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	keys, err := LoadDir("../testdata/keystore", testPassword)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	for _, key := range keys {
+		require.Equal(t, testSecret, hexutil.Encode(bls.SecretKeyToBytes(key.SecretKey)))
+	}
+
+	_, err = LoadDir("../testdata/keystore", "testpassword")
+	require.ErrorIs(t, err, ErrInvalidPassword)
+
+	keys, err = LoadDir(t.TempDir(), testPassword)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestNormalizePassword(t *testing.T) {
+	require.Equal(t, "testpassword🔑", string(normalizePassword(testPassword)))
+	require.Equal(t, "password", string(normalizePassword("pass\x00word\x7f\u0085")))
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	sk, pk, err := bls.GenerateNewKeypair()
+	require.NoError(t, err)
+
+	for _, kdf := range []KDFModule{
+		{Function: KDFScrypt, Params: KDFParams{DKLen: 32, N: 1024, R: 8, P: 1, Salt: hex.EncodeToString(make([]byte, 32))}},
+		{Function: KDFPBKDF2, Params: KDFParams{DKLen: 32, C: 1024, PRF: PRFHMACSHA256, Salt: hex.EncodeToString(make([]byte, 32))}},
+	} {
+		t.Run(kdf.Function, func(t *testing.T) {
+			ks, err := encrypt(sk, "hunter2", "m/12381/3600/0/0/0", kdf)
+			require.NoError(t, err)
+			require.Equal(t, Version, ks.Version)
+			require.Len(t, ks.UUID, 36)
+
+			file := filepath.Join(t.TempDir(), "keystore.json")
+			require.NoError(t, ks.WriteFile(file))
+
+			key, err := Load(file, "hunter2")
+			require.NoError(t, err)
+			require.True(t, sk.Equal(key.SecretKey))
+			require.True(t, pk.Equal(key.PublicKey))
+			require.Equal(t, "m/12381/3600/0/0/0", key.Path)
+			require.Equal(t, ks.UUID, key.UUID)
+
+			_, err = ks.Decrypt("hunter3")
+			require.ErrorIs(t, err, ErrInvalidPassword)
+		})
+	}
+}
+
+func TestDecryptRejectsTampering(t *testing.T) {
+	sk, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	kdf := KDFModule{Function: KDFPBKDF2, Params: KDFParams{DKLen: 32, C: 16, PRF: PRFHMACSHA256, Salt: "00"}}
+	ks, err := encrypt(sk, "hunter2", "", kdf)
+	require.NoError(t, err)
+
+	other, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	otherKs, err := encrypt(other, "hunter2", "", kdf)
+	require.NoError(t, err)
+
+	tampered := *ks
+	tampered.Pubkey = otherKs.Pubkey
+	_, err = tampered.Decrypt("hunter2")
+	require.ErrorIs(t, err, ErrPubkeyMismatch)
+
+	tampered = *ks
+	tampered.Version = 3
+	_, err = tampered.Decrypt("hunter2")
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	tampered = *ks
+	tampered.Crypto.KDF.Function = "argon2"
+	_, err = tampered.Decrypt("hunter2")
+	require.ErrorIs(t, err, ErrUnsupportedKDF)
+
+	_, err = Encrypt(sk, "hunter2", "", "argon2")
+	require.ErrorIs(t, err, ErrUnsupportedKDF)
+}
+
+func TestDecryptRejectsExpensiveKDF(t *testing.T) {
+	sk, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	ks, err := encrypt(sk, "hunter2", "", KDFModule{Function: KDFPBKDF2, Params: KDFParams{DKLen: 32, C: 16, PRF: PRFHMACSHA256, Salt: "00"}})
+	require.NoError(t, err)
+
+	for _, params := range []KDFParams{
+		{DKLen: 32, C: MaxPBKDF2C + 1, PRF: PRFHMACSHA256},
+		{DKLen: 32, C: -1, PRF: PRFHMACSHA256},
+		{DKLen: 1 << 30, C: 16, PRF: PRFHMACSHA256},
+	} {
+		tampered := *ks
+		tampered.Crypto.KDF.Params = params
+		_, err = tampered.Decrypt("hunter2")
+		require.ErrorIs(t, err, ErrUnsupportedKDF, params)
+	}
+
+	for _, params := range []KDFParams{
+		{DKLen: 32, N: 1 << 30, R: 8, P: 1},
+		{DKLen: 32, N: 1 << 20, R: 1 << 20, P: 1},
+		{DKLen: 32, N: 2, R: 8, P: MaxScryptP + 1},
+		{DKLen: 32, N: 2, R: -8, P: 1},
+	} {
+		tampered := *ks
+		tampered.Crypto.KDF = KDFModule{Function: KDFScrypt, Params: params}
+		_, err = tampered.Decrypt("hunter2")
+		require.ErrorIs(t, err, ErrUnsupportedKDF, params)
+	}
+}
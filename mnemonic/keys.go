@@ -0,0 +1,95 @@
+package mnemonic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/flashbots/go-boost-utils/bls"
+)
+
+// EIP-2334 paths of the keys of the validator with index i:
+// https://eips.ethereum.org/EIPS/eip-2334#validator-keys
+const (
+	purpose  = 12381
+	coinType = 3600
+
+	WithdrawalKeyPath = "m/12381/3600/%d/0"
+	SigningKeyPath    = "m/12381/3600/%d/0/0"
+)
+
+var ErrIndexOverflow = errors.New("validator index out of range")
+
+// KeyPair is a derived key with its public key and EIP-2334 path.
+type KeyPair struct {
+	Path      string
+	SecretKey *bls.SecretKey
+	PublicKey *bls.PublicKey
+}
+
+// ValidatorKeys are the keys of a validator.
+type ValidatorKeys struct {
+	Index      uint32
+	Signing    KeyPair
+	Withdrawal KeyPair
+}
+
+// DeriveValidatorKeys derives the keys of count validators starting at
+// index start from mnemonic and an optional passphrase.
+func DeriveValidatorKeys(mnemonic, passphrase string, start, count uint32) ([]*ValidatorKeys, error) {
+	if uint64(start)+uint64(count) > math.MaxUint32+1 {
+		return nil, ErrIndexOverflow
+	}
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+
+	master, err := bls.DeriveMasterSecretKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	// All validator keys share the prefix m/12381/3600.
+	parent, err := bls.DeriveChildSecretKey(master, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if parent, err = bls.DeriveChildSecretKey(parent, coinType); err != nil {
+		return nil, err
+	}
+
+	keys := make([]*ValidatorKeys, count)
+	for i := range keys {
+		index := start + uint32(i)
+		account, err := bls.DeriveChildSecretKey(parent, index)
+		if err != nil {
+			return nil, err
+		}
+		withdrawal, err := bls.DeriveChildSecretKey(account, 0)
+		if err != nil {
+			return nil, err
+		}
+		signing, err := bls.DeriveChildSecretKey(withdrawal, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = &ValidatorKeys{Index: index}
+		if keys[i].Withdrawal, err = newKeyPair(withdrawal, fmt.Sprintf(WithdrawalKeyPath, index)); err != nil {
+			return nil, err
+		}
+		if keys[i].Signing, err = newKeyPair(signing, fmt.Sprintf(SigningKeyPath, index)); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func newKeyPair(sk *bls.SecretKey, path string) (KeyPair, error) {
+	pk, err := bls.PublicKeyFromSecretKey(sk)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{Path: path, SecretKey: sk, PublicKey: pk}, nil
+}
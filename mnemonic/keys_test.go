@@ -0,0 +1,128 @@
+package mnemonic
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/stretchr/testify/require"
+)
+
+// Test case 0 of https://eips.ethereum.org/EIPS/eip-2333#test-cases, which
+// the staking-deposit-cli tests against as well, derives keys from a
+// mnemonic with a passphrase. The seed without a passphrase is that of the
+// BIP-39 reference vectors.
+func TestDepositCLIVectors(t *testing.T) {
+	for _, tc := range []struct {
+		Mnemonic   string
+		Passphrase string
+		Seed       string
+		Keys       map[string]string
+	}{
+		{
+			Mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			Passphrase: "TREZOR",
+			Seed:       "0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			Keys: map[string]string{
+				"m":   "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+				"m/0": "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+			},
+		},
+		{
+			Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			Seed:     "0x5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4",
+		},
+	} {
+		seed, err := Seed(tc.Mnemonic, tc.Passphrase)
+		require.NoError(t, err)
+		require.Equal(t, tc.Seed, hexutil.Encode(seed))
+		for path, expected := range tc.Keys {
+			sk, err := bls.DeriveSecretKeyFromPath(seed, path)
+			require.NoError(t, err)
+			require.Equal(t, expected, new(big.Int).SetBytes(bls.SecretKeyToBytes(sk)).String(), path)
+		}
+	}
+}
+
+// The keys were checked against blst's EIP-2333 implementation.
+func TestDeriveValidatorKeys(t *testing.T) {
+	for _, tc := range []struct {
+		Mnemonic   string
+		Passphrase string
+		Start      uint32
+		Keys       [][4]string
+	}{
+		{
+			Mnemonic:   trezorVectors[0].Mnemonic,
+			Passphrase: "TREZOR",
+			Start:      0,
+			Keys: [][4]string{
+				{
+					"0x032e6c3c7359223e127e9479afc521c4342f8903bc29ae01b671bcbcc98be0f6",
+					"0xb37247817d65f235d0053fa179be32aa86e37f0ddb05586146f0e3e9c418c06c6aec0c0ba3799b3e1357870caf7b4aa7",
+					"0x19f26b8e65b8aae8cba4ed0ef30a7b9e7d0b1838290b8e3f9e53c305d8987f9c",
+					"0xafddad6721fe97a6e42449b40f4a6c9dd856d75f3a746bd160859ab6b3feb1f23b6b98897c23c6f8a391ea4022e50732",
+				},
+				{
+					"0x51b94ab4703198edc37272cfc2d77e87e26fb1021eeec04e0a4f58e4c747653c",
+					"0xb0639f63f1518fff936c574afea99c0980c29a0837c29c055458c4d65a11c7e239d9c6e4dba172ac2b6932577cf3d0f3",
+					"0x70670eed99429279ca68d7c732e3cbb4b4cd80c09503dc3ad790ed654318fee4",
+					"0x96d758a697d31223333e1fa899e850ac49acb0488f403c4fd1756556b33470cb96cb07424e76a42adb179d3563eb0b5f",
+				},
+			},
+		},
+		{
+			Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title",
+			Start:    1,
+			Keys: [][4]string{
+				{
+					"0x3473182a2d610b97577932eeacbd30eb29334e843f0b0c13f736f4f7189d31f2",
+					"0x93fd1fcb62e0c8f84779fbf16d4e031291ffab80cd578e958a13f6e3253d638b1b171702597049581c7730bdf0af792e",
+					"0x54691b29ee4b9201f150809aafc566b5b3f106a90d707d348eb25b82b5c5a48b",
+					"0x93b0ca4d2e7bce72430d715ffa9fa56b69e3ef03b8b023b291f1c00696cdfcac28c9575d9c74764713a5f53aecba31cb",
+				},
+				{
+					"0x3b5124bb1b283f3f6fcb93eb747e8aadd170cd6e0da9f058eb00745208ca4903",
+					"0x847e40350ceb5ce04d34fd7cc747bf72795c622f467e3f25a2267d588fe6044a01755b4345c52cd0eff7bb6679bf7a4b",
+					"0x1e7bf41b7d61ae474634fe35e2440add9b6917e42f6d2ee901297ccb3db15159",
+					"0xb72f1216139fa897f9cc3c5b865f54c39d42e7119c0f97a1e89a2dcd778d0708858671af07e4c9046affe0347c31c36d",
+				},
+			},
+		},
+	} {
+		keys, err := DeriveValidatorKeys(tc.Mnemonic, tc.Passphrase, tc.Start, uint32(len(tc.Keys)))
+		require.NoError(t, err)
+		require.Len(t, keys, len(tc.Keys))
+		for i, key := range keys {
+			index := tc.Start + uint32(i)
+			require.Equal(t, index, key.Index)
+			require.Equal(t, tc.Keys[i][0], hexutil.Encode(bls.SecretKeyToBytes(key.Signing.SecretKey)))
+			require.Equal(t, tc.Keys[i][1], hexutil.Encode(bls.PublicKeyToBytes(key.Signing.PublicKey)))
+			require.Equal(t, tc.Keys[i][2], hexutil.Encode(bls.SecretKeyToBytes(key.Withdrawal.SecretKey)))
+			require.Equal(t, tc.Keys[i][3], hexutil.Encode(bls.PublicKeyToBytes(key.Withdrawal.PublicKey)))
+
+			// The paths lead to the same keys.
+			seed, err := Seed(tc.Mnemonic, tc.Passphrase)
+			require.NoError(t, err)
+			sk, err := bls.DeriveSecretKeyFromPath(seed, key.Signing.Path)
+			require.NoError(t, err)
+			require.True(t, sk.Equal(key.Signing.SecretKey))
+			sk, err = bls.DeriveSecretKeyFromPath(seed, key.Withdrawal.Path)
+			require.NoError(t, err)
+			require.True(t, sk.Equal(key.Withdrawal.SecretKey))
+		}
+	}
+}
+
+func TestDeriveValidatorKeysErrors(t *testing.T) {
+	_, err := DeriveValidatorKeys("abandon", "", 0, 1)
+	require.ErrorIs(t, err, ErrInvalidWordCount)
+	_, err = DeriveValidatorKeys(trezorVectors[0].Mnemonic, "", math.MaxUint32, 2)
+	require.ErrorIs(t, err, ErrIndexOverflow)
+
+	keys, err := DeriveValidatorKeys(trezorVectors[0].Mnemonic, "", math.MaxUint32, 1)
+	require.NoError(t, err)
+	require.Equal(t, "m/12381/3600/4294967295/0/0", keys[0].Signing.Path)
+}
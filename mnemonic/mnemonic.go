@@ -0,0 +1,141 @@
+// Package mnemonic implements BIP-39 mnemonics with the English word list
+// and derives validator keys from them like the staking deposit CLI does:
+// https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+package mnemonic
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	seedIterations = 2048
+	seedLen        = 64
+	bitsPerWord    = 11
+)
+
+var (
+	ErrInvalidEntropyLength = errors.New("entropy must be 128 to 256 bits in steps of 32")
+	ErrInvalidWordCount     = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrUnknownWord          = errors.New("unknown word in mnemonic")
+	ErrInvalidChecksum      = errors.New("invalid mnemonic checksum")
+)
+
+// english.txt is the BIP-39 English word list, whose SHA-256 is
+// 2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda.
+//
+//go:embed english.txt
+var english string
+
+var (
+	words     = strings.Fields(english)
+	wordIndex = func() map[string]int {
+		index := make(map[string]int, len(words))
+		for i, word := range words {
+			index[word] = i
+		}
+		return index
+	}()
+)
+
+// New returns a mnemonic for bits of random entropy, e.g. 256 for 24 words.
+func New(bits int) (string, error) {
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return "", ErrInvalidEntropyLength
+	}
+	entropy := make([]byte, bits/8)
+	defer clear(entropy)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return FromEntropy(entropy)
+}
+
+// FromEntropy encodes entropy of 16 to 32 bytes, in steps of 4, as a
+// mnemonic.
+func FromEntropy(entropy []byte) (string, error) {
+	if len(entropy)%4 != 0 || len(entropy) < 16 || len(entropy) > 32 {
+		return "", ErrInvalidEntropyLength
+	}
+	// The checksum is the first len(entropy)/4 bits of the hash, appended
+	// to the entropy.
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+	defer clear(data)
+
+	n := (len(entropy)*8 + len(entropy)/4) / bitsPerWord
+	mnemonic := make([]string, n)
+	for i := range mnemonic {
+		index := 0
+		for j := range bitsPerWord {
+			index = index<<1 | bit(data, i*bitsPerWord+j)
+		}
+		mnemonic[i] = words[index]
+	}
+	return strings.Join(mnemonic, " "), nil
+}
+
+// Entropy decodes mnemonic into the entropy it encodes, checking the
+// words and the checksum.
+func Entropy(mnemonic string) ([]byte, error) {
+	fields := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(fields)%3 != 0 || len(fields) < 12 || len(fields) > 24 {
+		return nil, ErrInvalidWordCount
+	}
+
+	data := make([]byte, (len(fields)*bitsPerWord+7)/8)
+	for i, word := range fields {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, ErrUnknownWord
+		}
+		for j := range bitsPerWord {
+			if index>>(bitsPerWord-1-j)&1 == 1 {
+				n := i*bitsPerWord + j
+				data[n/8] |= 0x80 >> (n % 8)
+			}
+		}
+	}
+
+	entropyLen := len(fields) * 4 / 3
+	entropy := data[:entropyLen]
+	checksum := sha256.Sum256(entropy)
+	for i := range entropyLen / 4 {
+		if bit(data, entropyLen*8+i) != bit(checksum[:], i) {
+			clear(data)
+			return nil, ErrInvalidChecksum
+		}
+	}
+	return entropy, nil
+}
+
+// Validate checks the words and the checksum of mnemonic.
+func Validate(mnemonic string) error {
+	entropy, err := Entropy(mnemonic)
+	clear(entropy)
+	return err
+}
+
+// Seed validates mnemonic and derives the 64-byte seed from it, protected
+// by an optional passphrase.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	if err := Validate(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key(sha512.New, normalized, []byte(salt), seedIterations, seedLen)
+}
+
+// bit returns the n-th bit of b, counting from the most significant bit of
+// b[0].
+func bit(b []byte, n int) int {
+	return int(b[n/8]>>(7-n%8)) & 1
+}
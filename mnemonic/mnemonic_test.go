@@ -0,0 +1,126 @@
+package mnemonic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json,
+// which the staking deposit CLI tests against, all with passphrase TREZOR.
+var trezorVectors = []struct {
+	Entropy  string
+	Mnemonic string
+	Seed     string
+}{
+	{
+		Entropy:  "0x00000000000000000000000000000000",
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Seed:     "0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		Entropy:  "0x7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Seed:     "0x2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		Entropy:  "0x80808080808080808080808080808080",
+		Mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		Seed:     "0xd71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		Entropy:  "0xffffffffffffffffffffffffffffffff",
+		Mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		Seed:     "0xac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		Entropy:  "0x000000000000000000000000000000000000000000000000",
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+		Seed:     "0x035895f2f481b1b0f01fcf8c289c794660b289981a78f8106447707fdd9666ca06da5a9a565181599b79f53b844d8a71dd9f439c52a3d7b3e8a79c906ac845fa",
+	},
+	{
+		Entropy:  "0x7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will",
+		Seed:     "0xf2b94508732bcbacbcc020faefecfc89feafa6649a5491b8c952cede496c214a0c7b3c392d168748f2d4a612bada0753b52a1c7ac53c1e93abd5c6320b9e95dd",
+	},
+	{
+		Entropy:  "0x808080808080808080808080808080808080808080808080",
+		Mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+		Seed:     "0x107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+	},
+	{
+		Entropy:  "0xffffffffffffffffffffffffffffffffffffffffffffffff",
+		Mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo when",
+		Seed:     "0x0cd6e5d827bb62eb8fc1e262254223817fd068a74b5b449cc2f667c3f1f985a76379b43348d952e2265b4cd129090758b3e3c2c49103b5051aac2eaeb890a528",
+	},
+	{
+		Entropy:  "0x0000000000000000000000000000000000000000000000000000000000000000",
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		Seed:     "0xbda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+}
+
+func TestTrezorVectors(t *testing.T) {
+	for _, tc := range trezorVectors {
+		mnemonic, err := FromEntropy(hexutil.MustDecode(tc.Entropy))
+		require.NoError(t, err)
+		require.Equal(t, tc.Mnemonic, mnemonic)
+
+		entropy, err := Entropy(tc.Mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, tc.Entropy, hexutil.Encode(entropy))
+
+		seed, err := Seed(tc.Mnemonic, "TREZOR")
+		require.NoError(t, err)
+		require.Equal(t, tc.Seed, hexutil.Encode(seed))
+	}
+}
+
+func TestWordList(t *testing.T) {
+	require.Len(t, words, 2048)
+	require.Len(t, wordIndex, 2048)
+	require.Equal(t, "abandon", words[0])
+	require.Equal(t, "zoo", words[2047])
+}
+
+func TestValidate(t *testing.T) {
+	valid := trezorVectors[0].Mnemonic
+	require.NoError(t, Validate(valid))
+	require.NoError(t, Validate("  "+strings.ReplaceAll(valid, " ", "\n ")+"\t"))
+
+	for _, tc := range []struct {
+		Name     string
+		Mnemonic string
+		Err      error
+	}{
+		{Name: "empty", Mnemonic: "", Err: ErrInvalidWordCount},
+		{Name: "11 words", Mnemonic: strings.Repeat("abandon ", 10) + "about", Err: ErrInvalidWordCount},
+		{Name: "27 words", Mnemonic: strings.Repeat("abandon ", 26) + "about", Err: ErrInvalidWordCount},
+		{Name: "unknown word", Mnemonic: strings.Repeat("abandon ", 11) + "aboot", Err: ErrUnknownWord},
+		{Name: "upper case", Mnemonic: strings.ToUpper(valid), Err: ErrUnknownWord},
+		{Name: "checksum", Mnemonic: strings.Repeat("abandon ", 11) + "abandon", Err: ErrInvalidChecksum},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.ErrorIs(t, Validate(tc.Mnemonic), tc.Err)
+			_, err := Seed(tc.Mnemonic, "")
+			require.ErrorIs(t, err, tc.Err)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := New(bits)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), bits/32*3)
+		require.NoError(t, Validate(mnemonic))
+	}
+	for _, bits := range []int{0, 96, 129, 288} {
+		_, err := New(bits)
+		require.ErrorIs(t, err, ErrInvalidEntropyLength)
+	}
+	_, err := FromEntropy(make([]byte, 17))
+	require.ErrorIs(t, err, ErrInvalidEntropyLength)
+}
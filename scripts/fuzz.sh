@@ -14,6 +14,8 @@ for file in $files; do
     for func in $funcs; do
         echo "${bold}[+] fuzzing $func in $file for ${FUZZTIME}${normal}"
         parent=$(dirname $file)
-        go test $parent -run=$func\$ -fuzz=$func\$ -fuzztime=${FUZZTIME}
+        # Differential fuzz tests against blst need the blst build tag.
+        tags=$(sed -n 's|^//go:build \(blst\).*|\1|p' $file)
+        go test ${tags:+-tags $tags} $parent -run=$func\$ -fuzz=$func\$ -fuzztime=${FUZZTIME}
     done
 done
@@ -0,0 +1,74 @@
+// Package signer abstracts over where the BLS secret key that signs a
+// message lives, so that callers work the same with keys held in memory
+// and keys held by a remote or hardware-backed signer.
+package signer
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+)
+
+// Signer signs on behalf of a single BLS key.
+type Signer interface {
+	// PublicKey returns the public key that signatures verify under.
+	PublicKey() phase0.BLSPubKey
+	// SignRoot signs a signing root as computed by ssz.ComputeSigningRoot.
+	SignRoot(ctx context.Context, root phase0.Root) (phase0.BLSSignature, error)
+}
+
+// Object is an SSZ object that can be signed, like ssz.ObjWithHashTreeRoot.
+type Object interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// ObjectSigner is implemented by Signers that need to see what they sign
+// rather than just its signing root, such as remote signers that check the
+// object or its domain before signing. ssz.SignMessageWithSigner prefers
+// SignObject over SignRoot when it is available.
+type ObjectSigner interface {
+	Signer
+	// SignObject signs obj under the domain d.
+	SignObject(ctx context.Context, obj Object, d phase0.Domain) (phase0.BLSSignature, error)
+}
+
+// LocalSigner is a Signer for a secret key held in memory.
+type LocalSigner struct {
+	sk *bls.SecretKey
+	pk phase0.BLSPubKey
+}
+
+// NewLocalSigner returns a Signer for sk.
+func NewLocalSigner(sk *bls.SecretKey) (*LocalSigner, error) {
+	pk, err := bls.PublicKeyFromSecretKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	s := &LocalSigner{sk: sk}
+	copy(s.pk[:], bls.PublicKeyToBytes(pk))
+	return s, nil
+}
+
+func (s *LocalSigner) PublicKey() phase0.BLSPubKey {
+	return s.pk
+}
+
+func (s *LocalSigner) SignRoot(ctx context.Context, root phase0.Root) (phase0.BLSSignature, error) {
+	if err := ctx.Err(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	sig, err := bls.SignE(s.sk, root[:])
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	var signature phase0.BLSSignature
+	copy(signature[:], bls.SignatureToBytes(sig))
+	return signature, nil
+}
+
+// Zeroize wipes the secret key. Signing fails with bls.ErrSecretKeyIsZero
+// afterwards.
+func (s *LocalSigner) Zeroize() {
+	s.sk.Zeroize()
+}
@@ -0,0 +1,57 @@
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalSigner(t *testing.T) {
+	sk, pk, err := bls.GenerateNewKeypair()
+	require.NoError(t, err)
+	s, err := NewLocalSigner(sk)
+	require.NoError(t, err)
+	pkBytes := s.PublicKey()
+	require.Equal(t, bls.PublicKeyToBytes(pk), pkBytes[:])
+
+	root := phase0.Root{0x01, 0x02, 0x03}
+	sig, err := s.SignRoot(context.Background(), root)
+	require.NoError(t, err)
+	require.Equal(t, bls.SignatureToBytes(bls.Sign(sk, root[:])), sig[:])
+
+	ok, err := bls.VerifySignatureBytes(root[:], sig[:], bls.PublicKeyToBytes(pk))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestLocalSignerZeroKey(t *testing.T) {
+	_, err := NewLocalSigner(new(bls.SecretKey))
+	require.ErrorIs(t, err, bls.ErrSecretKeyIsZero)
+}
+
+func TestLocalSignerZeroize(t *testing.T) {
+	sk, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	s, err := NewLocalSigner(sk)
+	require.NoError(t, err)
+
+	s.Zeroize()
+	require.True(t, sk.IsZero())
+	_, err = s.SignRoot(context.Background(), phase0.Root{})
+	require.ErrorIs(t, err, bls.ErrSecretKeyIsZero)
+}
+
+func TestLocalSignerCanceled(t *testing.T) {
+	sk, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	s, err := NewLocalSigner(sk)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.SignRoot(ctx, phase0.Root{})
+	require.ErrorIs(t, err, context.Canceled)
+}
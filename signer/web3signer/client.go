@@ -0,0 +1,280 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	builderApiBellatrix "github.com/attestantio/go-builder-client/api/bellatrix"
+	builderApiCapella "github.com/attestantio/go-builder-client/api/capella"
+	builderApiDeneb "github.com/attestantio/go-builder-client/api/deneb"
+	builderApiElectra "github.com/attestantio/go-builder-client/api/electra"
+	builderApiV1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/signer"
+	"github.com/flashbots/go-boost-utils/ssz"
+	"github.com/flashbots/go-boost-utils/utils"
+)
+
+const (
+	DefaultTimeout = 10 * time.Second
+	DefaultRetries = 2
+	DefaultBackoff = 100 * time.Millisecond
+
+	// maxResponseSize bounds the bodies read from the remote signer, whose
+	// largest response is the list of all its public keys.
+	maxResponseSize = 10 << 20
+)
+
+var (
+	ErrRequestFailed      = errors.New("remote signer request failed")
+	ErrUnknownPubkey      = errors.New("remote signer does not hold the key")
+	ErrInvalidResponse    = errors.New("invalid response from remote signer")
+	ErrInvalidSignature   = errors.New("remote signer returned an invalid signature")
+	ErrUnsupportedObject  = errors.New("object type cannot be signed remotely")
+	ErrUntypedSigningRoot = errors.New("remote signer only signs typed objects, not bare signing roots")
+)
+
+// Client talks to a Web3Signer-compatible remote signer.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient makes the Client send requests through c.
+func WithHTTPClient(c *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = c
+	}
+}
+
+// WithTimeout sets the timeout of a single attempt of a request.
+func WithTimeout(d time.Duration) Option {
+	return func(client *Client) {
+		client.timeout = d
+	}
+}
+
+// WithRetries sets how often failed requests are retried. The n-th retry
+// waits backoff⋅2ⁿ⁻¹. Only connection errors and 429 and 5xx responses are
+// retried.
+func WithRetries(n int, backoff time.Duration) Option {
+	return func(client *Client) {
+		client.retries = n
+		client.backoff = backoff
+	}
+}
+
+// NewClient returns a Client for the remote signer at url, e.g.
+// "http://localhost:9000".
+func NewClient(url string, opts ...Option) *Client {
+	c := &Client{
+		url:        strings.TrimSuffix(url, "/"),
+		httpClient: http.DefaultClient,
+		timeout:    DefaultTimeout,
+		retries:    DefaultRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// PublicKeys returns the public keys that the remote signer holds.
+func (c *Client) PublicKeys(ctx context.Context) ([]phase0.BLSPubKey, error) {
+	body, _, err := c.do(ctx, http.MethodGet, PublicKeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	pks := make([]phase0.BLSPubKey, len(keys))
+	for i, key := range keys {
+		if pks[i], err = utils.HexToPubkey(key); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+		}
+	}
+	return pks, nil
+}
+
+// Signer returns a Signer for the key pk held by the remote signer.
+func (c *Client) Signer(pk phase0.BLSPubKey) (*Signer, error) {
+	prepared, err := bls.PreparePublicKeyBytes(pk[:])
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{client: c, pk: pk, prepared: prepared}, nil
+}
+
+// Signer is a signer.Signer for a key held by a remote signer. Every
+// signature it returns has been verified against the public key.
+type Signer struct {
+	client   *Client
+	pk       phase0.BLSPubKey
+	prepared *bls.PreparedPublicKey
+}
+
+var _ signer.ObjectSigner = (*Signer)(nil)
+
+func (s *Signer) PublicKey() phase0.BLSPubKey {
+	return s.pk
+}
+
+// SignRoot always fails with ErrUntypedSigningRoot: Web3Signer signs typed
+// objects only, so that it can check them. Use SignObject instead.
+func (s *Signer) SignRoot(context.Context, phase0.Root) (phase0.BLSSignature, error) {
+	return phase0.BLSSignature{}, ErrUntypedSigningRoot
+}
+
+// SignObject signs a validator registration or a builder bid of any fork.
+func (s *Signer) SignObject(ctx context.Context, obj signer.Object, d phase0.Domain) (phase0.BLSSignature, error) {
+	switch obj := obj.(type) {
+	case *builderApiV1.ValidatorRegistration:
+		return s.SignValidatorRegistration(ctx, obj, d)
+	case *VersionedBuilderBid:
+		return s.SignBuilderBid(ctx, obj, d)
+	case *builderApiBellatrix.BuilderBid:
+		return s.SignBuilderBid(ctx, &VersionedBuilderBid{Version: spec.DataVersionBellatrix, Bellatrix: obj}, d)
+	case *builderApiCapella.BuilderBid:
+		return s.SignBuilderBid(ctx, &VersionedBuilderBid{Version: spec.DataVersionCapella, Capella: obj}, d)
+	case *builderApiDeneb.BuilderBid:
+		return s.SignBuilderBid(ctx, &VersionedBuilderBid{Version: spec.DataVersionDeneb, Deneb: obj}, d)
+	case *builderApiElectra.BuilderBid:
+		return s.SignBuilderBid(ctx, &VersionedBuilderBid{Version: spec.DataVersionElectra, Electra: obj}, d)
+	default:
+		return phase0.BLSSignature{}, fmt.Errorf("%w: %T", ErrUnsupportedObject, obj)
+	}
+}
+
+// SignValidatorRegistration signs reg under the domain d, which is
+// normally ssz.DomainBuilder.
+func (s *Signer) SignValidatorRegistration(ctx context.Context, reg *builderApiV1.ValidatorRegistration, d phase0.Domain) (phase0.BLSSignature, error) {
+	return s.sign(ctx, reg, d, &SignRequest{Type: SignTypeValidatorRegistration, ValidatorRegistration: reg})
+}
+
+// SignBuilderBid signs bid under the domain d, which is normally
+// ssz.DomainBuilder.
+func (s *Signer) SignBuilderBid(ctx context.Context, bid *VersionedBuilderBid, d phase0.Domain) (phase0.BLSSignature, error) {
+	return s.sign(ctx, bid, d, &SignRequest{Type: SignTypeBuilderBid, BuilderBid: bid})
+}
+
+func (s *Signer) sign(ctx context.Context, obj ssz.ObjWithHashTreeRoot, d phase0.Domain, req *SignRequest) (phase0.BLSSignature, error) {
+	root, err := ssz.ComputeSigningRoot(obj, d)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	req.SigningRoot = (*common.Hash)(&root)
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	body, contentType, err := s.client.do(ctx, http.MethodPost, SignPath+hexutil.Encode(s.pk[:]), reqBody)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	sigBytes, err := parseSignature(body, contentType)
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	if ok, err := s.prepared.VerifyBytes(root[:], sigBytes); err != nil || !ok {
+		return phase0.BLSSignature{}, ErrInvalidSignature
+	}
+
+	var sig phase0.BLSSignature
+	copy(sig[:], sigBytes)
+	return sig, nil
+}
+
+// parseSignature reads the signature from a JSON or plain text response.
+func parseSignature(body []byte, contentType string) ([]byte, error) {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/json" {
+		var resp SignResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Signature, nil
+	}
+	return hexutil.Decode(strings.TrimSpace(string(body)))
+}
+
+// do sends a request, retrying it as configured, and returns the body and
+// content type of the response.
+func (c *Client) do(ctx context.Context, method, path string, reqBody []byte) ([]byte, string, error) {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var (
+			body        []byte
+			contentType string
+			retry       bool
+		)
+		body, contentType, retry, err = c.attempt(ctx, method, path, reqBody)
+		if err == nil || !retry || attempt >= c.retries {
+			return body, contentType, err
+		}
+
+		timer := time.NewTimer(c.backoff << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends a request once and reports whether a failure is worth
+// retrying.
+func (c *Client) attempt(ctx context.Context, method, path string, reqBody []byte) (body []byte, contentType string, retry bool, err error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(attemptCtx, method, c.url+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, "", false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// A timed out attempt is retried, unless the caller gave up.
+		return nil, "", ctx.Err() == nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, "", true, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return body, resp.Header.Get("Content-Type"), false, nil
+	case resp.StatusCode == http.StatusNotFound && strings.HasPrefix(path, SignPath):
+		return nil, "", false, ErrUnknownPubkey
+	default:
+		retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, "", retry, fmt.Errorf("%w: %s: %s", ErrRequestFailed, resp.Status, strings.TrimSpace(string(body)))
+	}
+}
@@ -0,0 +1,211 @@
+package web3signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	builderApiV1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+// standIn is a minimal remote signer that signs whatever signing root it
+// is sent.
+type standIn struct {
+	sk       *bls.SecretKey
+	pk       phase0.BLSPubKey
+	requests atomic.Int32
+
+	// failures is the number of requests answered with 503 before
+	// succeeding.
+	failures  int32
+	delay     time.Duration
+	plainText bool
+	// signingKey signs instead of sk if set.
+	signingKey *bls.SecretKey
+	lastReq    atomic.Pointer[SignRequest]
+}
+
+func newStandIn(t *testing.T) *standIn {
+	t.Helper()
+	sk, pk, err := bls.GenerateNewKeypair()
+	require.NoError(t, err)
+	s := &standIn{sk: sk}
+	copy(s.pk[:], bls.PublicKeyToBytes(pk))
+	return s
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.requests.Add(1) <= s.failures {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	select {
+	case <-time.After(s.delay):
+	case <-r.Context().Done():
+		return
+	}
+
+	if r.URL.Path == PublicKeysPath {
+		_ = json.NewEncoder(w).Encode([]string{hexutil.Encode(s.pk[:])})
+		return
+	}
+	if r.URL.Path != SignPath+hexutil.Encode(s.pk[:]) {
+		http.Error(w, "unknown key", http.StatusNotFound)
+		return
+	}
+	req := new(SignRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.SigningRoot == nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	s.lastReq.Store(req)
+
+	sk := s.sk
+	if s.signingKey != nil {
+		sk = s.signingKey
+	}
+	sig := bls.SignatureToBytes(bls.Sign(sk, req.SigningRoot[:]))
+	if s.plainText {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, hexutil.Encode(sig))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&SignResponse{Signature: sig})
+}
+
+func newTestSigner(t *testing.T, s *standIn, opts ...Option) *Signer {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	remote, err := NewClient(srv.URL, opts...).Signer(s.pk)
+	require.NoError(t, err)
+	return remote
+}
+
+func testRegistration(pk phase0.BLSPubKey) *builderApiV1.ValidatorRegistration {
+	return &builderApiV1.ValidatorRegistration{
+		FeeRecipient: bellatrix.ExecutionAddress{0x42},
+		GasLimit:     30_000_000,
+		Timestamp:    time.Unix(1_700_000_000, 0),
+		Pubkey:       pk,
+	}
+}
+
+func TestClientPublicKeys(t *testing.T) {
+	s := newStandIn(t)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	pks, err := NewClient(srv.URL + "/").PublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []phase0.BLSPubKey{s.pk}, pks)
+}
+
+func TestSignValidatorRegistration(t *testing.T) {
+	s := newStandIn(t)
+	remote := newTestSigner(t, s)
+	require.Equal(t, s.pk, remote.PublicKey())
+	reg := testRegistration(s.pk)
+
+	sig, err := ssz.SignMessageWithSigner(context.Background(), reg, ssz.DomainBuilder, remote)
+	require.NoError(t, err)
+	expected, err := ssz.SignMessage(reg, ssz.DomainBuilder, s.sk)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	require.Equal(t, SignTypeValidatorRegistration, s.lastReq.Load().Type)
+	require.Equal(t, reg, s.lastReq.Load().ValidatorRegistration)
+	root, err := ssz.ComputeSigningRoot(reg, ssz.DomainBuilder)
+	require.NoError(t, err)
+	require.Equal(t, root[:], s.lastReq.Load().SigningRoot[:])
+}
+
+func TestSignBuilderBid(t *testing.T) {
+	s := newStandIn(t)
+	s.plainText = true
+	remote := newTestSigner(t, s)
+	bid := denebBuilderBid(t)
+
+	for _, obj := range []ssz.ObjWithHashTreeRoot{bid, bid.Deneb} {
+		sig, err := ssz.SignMessageWithSigner(context.Background(), obj, ssz.DomainBuilder, remote)
+		require.NoError(t, err)
+		expected, err := ssz.SignMessage(bid, ssz.DomainBuilder, s.sk)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+		require.Equal(t, SignTypeBuilderBid, s.lastReq.Load().Type)
+		require.Equal(t, bid, s.lastReq.Load().BuilderBid)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	ctx := context.Background()
+	s := newStandIn(t)
+	remote := newTestSigner(t, s)
+	reg := testRegistration(s.pk)
+
+	_, err := remote.SignRoot(ctx, phase0.Root{})
+	require.ErrorIs(t, err, ErrUntypedSigningRoot)
+	_, err = remote.SignObject(ctx, &phase0.Checkpoint{}, ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrUnsupportedObject)
+
+	// The remote signer signs with a different key.
+	s.signingKey, err = bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	_, err = remote.SignValidatorRegistration(ctx, reg, ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	// The remote signer does not hold the key.
+	other := newStandIn(t)
+	remote, err = remote.client.Signer(other.pk)
+	require.NoError(t, err)
+	_, err = remote.SignValidatorRegistration(ctx, reg, ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrUnknownPubkey)
+
+	_, err = NewClient("http://localhost").Signer(phase0.BLSPubKey{})
+	require.Error(t, err)
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+	s := newStandIn(t)
+	s.failures = 2
+	remote := newTestSigner(t, s, WithRetries(2, time.Millisecond))
+	_, err := remote.SignValidatorRegistration(ctx, testRegistration(s.pk), ssz.DomainBuilder)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), s.requests.Load())
+
+	s = newStandIn(t)
+	s.failures = 2
+	remote = newTestSigner(t, s, WithRetries(1, time.Millisecond))
+	_, err = remote.SignValidatorRegistration(ctx, testRegistration(s.pk), ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrRequestFailed)
+	require.Equal(t, int32(2), s.requests.Load())
+}
+
+func TestClientTimeout(t *testing.T) {
+	s := newStandIn(t)
+	s.delay = time.Second
+	remote := newTestSigner(t, s, WithTimeout(10*time.Millisecond), WithRetries(1, time.Millisecond))
+	_, err := remote.SignValidatorRegistration(context.Background(), testRegistration(s.pk), ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrRequestFailed)
+	require.Equal(t, int32(2), s.requests.Load())
+
+	// A canceled context is not retried.
+	s.requests.Store(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = remote.SignValidatorRegistration(ctx, testRegistration(s.pk), ssz.DomainBuilder)
+	require.ErrorIs(t, err, context.Canceled)
+}
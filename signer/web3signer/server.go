@@ -0,0 +1,207 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/keystore"
+	"github.com/flashbots/go-boost-utils/signer"
+	"github.com/flashbots/go-boost-utils/ssz"
+	"github.com/flashbots/go-boost-utils/utils"
+)
+
+const (
+	UpcheckPath = "/upcheck"
+
+	// maxRequestSize bounds the bodies of signing requests. Builder bids
+	// with all their blob commitments stay well below it.
+	maxRequestSize = 1 << 20
+)
+
+var (
+	ErrUnknownSignType     = errors.New("unknown sign type")
+	ErrMissingPayload      = errors.New("missing payload for sign type")
+	ErrSigningRootMismatch = errors.New("signing root does not match payload and domain")
+	ErrPubkeyMismatch      = errors.New("payload pubkey does not match signing key")
+)
+
+// Keys provides the signers a Server signs with.
+type Keys interface {
+	// PublicKeys returns the public keys of all signers.
+	PublicKeys() []phase0.BLSPubKey
+	// Signer returns the signer for pk, if any.
+	Signer(pk phase0.BLSPubKey) (signer.Signer, bool)
+}
+
+// KeySet is a fixed set of signers.
+type KeySet struct {
+	signers map[phase0.BLSPubKey]signer.Signer
+}
+
+var _ Keys = (*KeySet)(nil)
+
+// NewKeySet returns a KeySet of signers. Of signers with the same public key
+// the last one is used.
+func NewKeySet(signers ...signer.Signer) *KeySet {
+	ks := &KeySet{signers: make(map[phase0.BLSPubKey]signer.Signer, len(signers))}
+	for _, s := range signers {
+		ks.signers[s.PublicKey()] = s
+	}
+	return ks
+}
+
+// LoadKeySet loads a KeySet from the keystores in dir, which must all be
+// encrypted with password.
+func LoadKeySet(dir, password string) (*KeySet, error) {
+	keys, err := keystore.LoadDir(dir, password)
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]signer.Signer, len(keys))
+	for i, key := range keys {
+		if signers[i], err = signer.NewLocalSigner(key.SecretKey); err != nil {
+			return nil, err
+		}
+	}
+	return NewKeySet(signers...), nil
+}
+
+// PublicKeys returns the public keys of the set in ascending order.
+func (ks *KeySet) PublicKeys() []phase0.BLSPubKey {
+	pks := make([]phase0.BLSPubKey, 0, len(ks.signers))
+	for pk := range ks.signers {
+		pks = append(pks, pk)
+	}
+	slices.SortFunc(pks, func(a, b phase0.BLSPubKey) int {
+		return strings.Compare(string(a[:]), string(b[:]))
+	})
+	return pks
+}
+
+func (ks *KeySet) Signer(pk phase0.BLSPubKey) (signer.Signer, bool) {
+	s, ok := ks.signers[pk]
+	return s, ok
+}
+
+// Server serves the Web3Signer eth2 signing API for validator registrations
+// and builder bids. Both are signed under the builder domain of the
+// network, so requests whose signing root was computed for another network
+// or domain are rejected.
+type Server struct {
+	keys   Keys
+	domain phase0.Domain
+	mux    *http.ServeMux
+}
+
+// NewServer returns a Server that signs with keys for the network with
+// the given genesis fork version.
+func NewServer(keys Keys, genesisForkVersion phase0.Version) *Server {
+	s := &Server{
+		keys:   keys,
+		domain: ssz.ComputeDomain(ssz.DomainTypeAppBuilder, genesisForkVersion, phase0.Root{}),
+		mux:    http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET "+UpcheckPath, s.handleUpcheck)
+	s.mux.HandleFunc("GET "+PublicKeysPath, s.handlePublicKeys)
+	s.mux.HandleFunc("POST "+SignPath+"{pubkey}", s.handleSign)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleUpcheck(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte("OK"))
+}
+
+func (s *Server) handlePublicKeys(w http.ResponseWriter, _ *http.Request) {
+	pks := s.keys.PublicKeys()
+	keys := make([]string, len(pks))
+	for i, pk := range pks {
+		keys[i] = hexutil.Encode(pk[:])
+	}
+	writeJSON(w, keys)
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	pk, err := utils.HexToPubkey(r.PathValue("pubkey"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	keySigner, ok := s.keys.Signer(pk)
+	if !ok {
+		http.Error(w, ErrUnknownPubkey.Error(), http.StatusNotFound)
+		return
+	}
+
+	req := new(SignRequest)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	root, err := s.signingRoot(pk, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sig, err := keySigner.SignRoot(r.Context(), root)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(w, &SignResponse{Signature: sig[:]})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(hexutil.Encode(sig[:])))
+}
+
+// signingRoot computes the signing root of the payload of req and checks it
+// against the one in req, if any. A validator registration must be for pk,
+// the key it is signed with.
+func (s *Server) signingRoot(pk phase0.BLSPubKey, req *SignRequest) (phase0.Root, error) {
+	var obj ssz.ObjWithHashTreeRoot
+	switch req.Type {
+	case SignTypeValidatorRegistration:
+		if req.ValidatorRegistration != nil {
+			if req.ValidatorRegistration.Pubkey != pk {
+				return phase0.Root{}, ErrPubkeyMismatch
+			}
+			obj = req.ValidatorRegistration
+		}
+	case SignTypeBuilderBid:
+		if req.BuilderBid != nil {
+			obj = req.BuilderBid
+		}
+	default:
+		return phase0.Root{}, fmt.Errorf("%w: %s", ErrUnknownSignType, req.Type)
+	}
+	if obj == nil {
+		return phase0.Root{}, fmt.Errorf("%w: %s", ErrMissingPayload, req.Type)
+	}
+
+	root, err := ssz.ComputeSigningRoot(obj, s.domain)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	if req.SigningRoot != nil && *req.SigningRoot != root {
+		return phase0.Root{}, ErrSigningRootMismatch
+	}
+	return root, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,123 @@
+package web3signer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/signer"
+	"github.com/flashbots/go-boost-utils/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *signer.LocalSigner) {
+	t.Helper()
+	sk, err := bls.GenerateRandomSecretKey()
+	require.NoError(t, err)
+	local, err := signer.NewLocalSigner(sk)
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewServer(NewKeySet(local), phase0.Version{}))
+	t.Cleanup(srv.Close)
+	return srv, local
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	srv, local := newTestServer(t)
+	client := NewClient(srv.URL)
+
+	pks, err := client.PublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []phase0.BLSPubKey{local.PublicKey()}, pks)
+
+	remote, err := client.Signer(local.PublicKey())
+	require.NoError(t, err)
+	for _, obj := range []ssz.ObjWithHashTreeRoot{testRegistration(local.PublicKey()), denebBuilderBid(t)} {
+		sig, err := ssz.SignMessageWithSigner(ctx, obj, ssz.DomainBuilder, remote)
+		require.NoError(t, err)
+		expected, err := ssz.SignMessageWithSigner(ctx, obj, ssz.DomainBuilder, local)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	}
+
+	// Signing for another network is refused.
+	domain := ssz.ComputeDomain(ssz.DomainTypeAppBuilder, phase0.Version{0x00, 0x00, 0x10, 0x20}, phase0.Root{})
+	_, err = remote.SignValidatorRegistration(ctx, testRegistration(local.PublicKey()), domain)
+	require.ErrorIs(t, err, ErrRequestFailed)
+	require.ErrorContains(t, err, ErrSigningRootMismatch.Error())
+
+	// So is registering another validator.
+	otherPk := phase0.BLSPubKey(bls.PublicKeyToBytes(mustPublicKey(t)))
+	_, err = remote.SignValidatorRegistration(ctx, testRegistration(otherPk), ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrRequestFailed)
+	require.ErrorContains(t, err, ErrPubkeyMismatch.Error())
+
+	other, err := client.Signer(otherPk)
+	require.NoError(t, err)
+	_, err = other.SignValidatorRegistration(ctx, testRegistration(local.PublicKey()), ssz.DomainBuilder)
+	require.ErrorIs(t, err, ErrUnknownPubkey)
+}
+
+func TestServerBadRequests(t *testing.T) {
+	srv, local := newTestServer(t)
+	pk := local.PublicKey()
+	url := srv.URL + SignPath + hexutil.Encode(pk[:])
+
+	for _, tc := range []struct {
+		Name     string
+		URL      string
+		Body     string
+		Status   int
+		Response string
+	}{
+		{Name: "invalid pubkey", URL: srv.URL + SignPath + "0x1234", Body: `{}`, Status: http.StatusBadRequest},
+		{Name: "invalid body", URL: url, Body: `{`, Status: http.StatusBadRequest},
+		{Name: "unknown type", URL: url, Body: `{"type":"BLOCK_V2"}`, Status: http.StatusBadRequest, Response: ErrUnknownSignType.Error()},
+		{Name: "missing payload", URL: url, Body: `{"type":"VALIDATOR_REGISTRATION"}`, Status: http.StatusBadRequest, Response: ErrMissingPayload.Error()},
+		{Name: "text response", URL: url, Body: `{"type":"BUILDER_BID","builder_bid":` + denebBuilderBidJSON + `}`, Status: http.StatusOK, Response: "0x"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			resp, err := http.Post(tc.URL, "application/json", strings.NewReader(tc.Body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.Status, resp.StatusCode, string(body))
+			require.Contains(t, string(body), tc.Response)
+		})
+	}
+
+	resp, err := http.Get(srv.URL + UpcheckPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("../../testdata/keystore/pbkdf2.json")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore.json"), data, 0o600))
+
+	keys, err := LoadKeySet(dir, "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511")
+	require.NoError(t, err)
+	pk := hexutil.MustDecode("0x9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07")
+	require.Equal(t, []phase0.BLSPubKey{phase0.BLSPubKey(pk)}, keys.PublicKeys())
+	_, ok := keys.Signer(phase0.BLSPubKey(pk))
+	require.True(t, ok)
+}
+
+func mustPublicKey(t *testing.T) *bls.PublicKey {
+	t.Helper()
+	_, pk, err := bls.GenerateNewKeypair()
+	require.NoError(t, err)
+	return pk
+}
@@ -0,0 +1,146 @@
+// Package web3signer implements the eth2 signing API of Web3Signer for
+// validator registrations and builder bids.
+package web3signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	builderApiBellatrix "github.com/attestantio/go-builder-client/api/bellatrix"
+	builderApiCapella "github.com/attestantio/go-builder-client/api/capella"
+	builderApiDeneb "github.com/attestantio/go-builder-client/api/deneb"
+	builderApiElectra "github.com/attestantio/go-builder-client/api/electra"
+	builderApiV1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	PublicKeysPath = "/api/v1/eth2/publicKeys"
+	SignPath       = "/api/v1/eth2/sign/"
+
+	// SignTypeValidatorRegistration is the Web3Signer type for signing a
+	// builderApiV1.ValidatorRegistration.
+	SignTypeValidatorRegistration = "VALIDATOR_REGISTRATION"
+	// SignTypeBuilderBid signs a VersionedBuilderBid. It is not part of
+	// Web3Signer, which has no notion of builders.
+	SignTypeBuilderBid = "BUILDER_BID"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported builder bid version")
+	ErrNilBuilderBid      = errors.New("nil builder bid")
+)
+
+// SignRequest is the body of a signing request.
+type SignRequest struct {
+	Type                  string                              `json:"type"`
+	SigningRoot           *common.Hash                        `json:"signingRoot,omitempty"` //nolint:tagliatelle // Web3Signer wire format
+	ValidatorRegistration *builderApiV1.ValidatorRegistration `json:"validator_registration,omitempty"`
+	BuilderBid            *VersionedBuilderBid                `json:"builder_bid,omitempty"`
+}
+
+// SignResponse is the body of a successful signing request when JSON was
+// asked for. Otherwise the signature is returned as plain text.
+type SignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// VersionedBuilderBid is an unsigned builder bid of any fork. It is encoded
+// like the builder API encodes versioned responses. Fulu reuses the bid of
+// Electra.
+type VersionedBuilderBid struct {
+	Version   spec.DataVersion
+	Bellatrix *builderApiBellatrix.BuilderBid
+	Capella   *builderApiCapella.BuilderBid
+	Deneb     *builderApiDeneb.BuilderBid
+	Electra   *builderApiElectra.BuilderBid
+}
+
+type versionedBuilderBidJSON struct {
+	Version spec.DataVersion `json:"version"`
+	Data    json.RawMessage  `json:"data"`
+}
+
+type builderBid interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// bid returns the bid of b.Version.
+func (b *VersionedBuilderBid) bid() (builderBid, error) {
+	switch b.Version {
+	case spec.DataVersionBellatrix:
+		if b.Bellatrix == nil {
+			return nil, ErrNilBuilderBid
+		}
+		return b.Bellatrix, nil
+	case spec.DataVersionCapella:
+		if b.Capella == nil {
+			return nil, ErrNilBuilderBid
+		}
+		return b.Capella, nil
+	case spec.DataVersionDeneb:
+		if b.Deneb == nil {
+			return nil, ErrNilBuilderBid
+		}
+		return b.Deneb, nil
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		if b.Electra == nil {
+			return nil, ErrNilBuilderBid
+		}
+		return b.Electra, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, b.Version)
+	}
+}
+
+func (b *VersionedBuilderBid) HashTreeRoot() ([32]byte, error) {
+	bid, err := b.bid()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return bid.HashTreeRoot()
+}
+
+func (b *VersionedBuilderBid) MarshalJSON() ([]byte, error) {
+	bid, err := b.bid()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(bid)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&versionedBuilderBidJSON{Version: b.Version, Data: data})
+}
+
+func (b *VersionedBuilderBid) UnmarshalJSON(input []byte) error {
+	var v versionedBuilderBidJSON
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	*b = VersionedBuilderBid{Version: v.Version}
+	var bid builderBid
+	switch v.Version {
+	case spec.DataVersionBellatrix:
+		b.Bellatrix = new(builderApiBellatrix.BuilderBid)
+		bid = b.Bellatrix
+	case spec.DataVersionCapella:
+		b.Capella = new(builderApiCapella.BuilderBid)
+		bid = b.Capella
+	case spec.DataVersionDeneb:
+		b.Deneb = new(builderApiDeneb.BuilderBid)
+		bid = b.Deneb
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		b.Electra = new(builderApiElectra.BuilderBid)
+		bid = b.Electra
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedVersion, v.Version)
+	}
+	if len(v.Data) == 0 || string(v.Data) == "null" {
+		return ErrNilBuilderBid
+	}
+	return json.Unmarshal(v.Data, bid)
+}
@@ -0,0 +1,73 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/stretchr/testify/require"
+)
+
+const denebBuilderBidJSON = `{
+	"version": "deneb",
+	"data": {
+		"header": {
+			"parent_hash": "0x17f4eeae822cc81533016678413443b95e34517e67f12b4a3a92ff6b66f972ef",
+			"fee_recipient": "0x58E809C71e4885cB7B3f1D5c793AB04eD239d779",
+			"state_root": "0x3d6e230e6eceb8f3db582777b1500b8b31b9d268339e7b32bba8d6f1311b211d",
+			"receipts_root": "0xea760203509bdde017a506b12c825976d12b04db7bce9eca9e1ed007056a3f36",
+			"logs_bloom": "0x0c803a8d3c6642adee3185bd914c599317d96487831dabda82461f65700b2528781bdadf785664f9d8b11c4ee1139dfeb056125d2abd67e379cabc6d58f1c3ea304b97cf17fcd8a4c53f4dedeaa041acce062fc8fbc88ffc111577db4a936378749f2fd82b4bfcb880821dd5cbefee984bc1ad116096a64a44a2aac8a1791a7ad3a53d91c584ac69a8973daed6daee4432a198c9935fa0e5c2a4a6ca78b821a5b046e571a5c0961f469d40e429066755fec611afe25b560db07f989933556ce0cea4070ca47677b007b4b9857fc092625f82c84526737dc98e173e34fe6e4d0f1a400fd994298b7c2fa8187331c333c415f0499836ff0eed5c762bf570e67b44",
+			"prev_randao": "0x76ff751467270668df463600d26dba58297a986e649bac84ea856712d4779c00",
+			"block_number": "2983837628677007840",
+			"gas_limit": "6738255228996962210",
+			"gas_used": "5573520557770513197",
+			"timestamp": "1744720080366521389",
+			"extra_data": "0xc648",
+			"base_fee_per_gas": "88770397543877639215846057887940126737648744594802753726778414602657613619599",
+			"block_hash": "0x42c294e902bfc9884c1ce5fef8d77d8ec2d3b7da26f4b1c9a5fc3d4f2e4c8ed8",
+			"transactions_root": "0x8457d0eb7611a621e7a094059f087415ffcfc91714fc184a1f3c48db06b4d08b",
+			"withdrawals_root": "0x5c1a7e3e0eab917a7c0d677c6b692ed55ce05f07d572e09b1c06d558f474ea7a",
+			"blob_gas_used": "15569019495489484386",
+			"excess_blob_gas": "18045029271823015427"
+		},
+		"blob_kzg_commitments": [
+			"0x8dab030c51e16e84be9caab84ee3d0b8bbec1db4a0e4de76439da8424d9b957370a10a78851f97e4b54d2ce1ab0d686f"
+		],
+		"value": "1234567890",
+		"pubkey": "0x8a1d7b8dd64e0aafe7ea7b6c95065c9364cf99d38470c12ee807d55f7de1529ad29ce2c422e0b65e3d5a05c02caca249"
+	}
+}`
+
+func denebBuilderBid(t *testing.T) *VersionedBuilderBid {
+	t.Helper()
+	bid := new(VersionedBuilderBid)
+	require.NoError(t, json.Unmarshal([]byte(denebBuilderBidJSON), bid))
+	return bid
+}
+
+func TestVersionedBuilderBidJSON(t *testing.T) {
+	bid := denebBuilderBid(t)
+	require.Equal(t, spec.DataVersionDeneb, bid.Version)
+	require.NotNil(t, bid.Deneb)
+
+	root, err := bid.HashTreeRoot()
+	require.NoError(t, err)
+	expected, err := bid.Deneb.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	data, err := json.Marshal(bid)
+	require.NoError(t, err)
+	require.JSONEq(t, denebBuilderBidJSON, string(data))
+}
+
+func TestVersionedBuilderBidErrors(t *testing.T) {
+	var bid VersionedBuilderBid
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"version":"phase0","data":{}}`), &bid), ErrUnsupportedVersion)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"version":"deneb","data":null}`), &bid), ErrNilBuilderBid)
+
+	_, err := (&VersionedBuilderBid{Version: spec.DataVersionCapella}).HashTreeRoot()
+	require.ErrorIs(t, err, ErrNilBuilderBid)
+	_, err = json.Marshal(&VersionedBuilderBid{Version: spec.DataVersionAltair})
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
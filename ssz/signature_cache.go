@@ -0,0 +1,110 @@
+package ssz
+
+import (
+	"sync/atomic"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/internal/lru"
+)
+
+// SignatureKey identifies a signature of a signing root by a public key.
+type SignatureKey struct {
+	PublicKey   phase0.BLSPubKey
+	SigningRoot phase0.Root
+	Signature   phase0.BLSSignature
+}
+
+// SignatureCacheStats is a snapshot of the usage of a SignatureCache.
+type SignatureCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+// SignatureCache remembers signatures that verified, so that messages
+// which are sent again, like validator registrations every epoch, skip the
+// pairing. Invalid signatures are not cached. It is safe for concurrent
+// use.
+type SignatureCache struct {
+	valid     *lru.Cache[SignatureKey, struct{}]
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// NewSignatureCache returns a cache holding at most size signatures. If
+// onEvict is not nil, it is called with every signature evicted to make
+// room for a new one.
+func NewSignatureCache(size int, onEvict func(SignatureKey)) *SignatureCache {
+	c := new(SignatureCache)
+	c.valid = lru.New(size, func(key SignatureKey, _ struct{}) {
+		c.evictions.Add(1)
+		if onEvict != nil {
+			onEvict(key)
+		}
+	})
+	return c
+}
+
+// VerifySignatureBytes is like bls.VerifySignatureBytes but returns true
+// without verifying if the signature is in the cache, and adds it if it
+// verifies.
+func (c *SignatureCache) VerifySignatureBytes(msg, sigBytes, pkBytes []byte) (bool, error) {
+	if len(msg) != len(phase0.Root{}) || len(sigBytes) != bls.SignatureLength || len(pkBytes) != bls.PublicKeyLength {
+		return bls.VerifySignatureBytes(msg, sigBytes, pkBytes)
+	}
+	key := SignatureKey{
+		PublicKey:   phase0.BLSPubKey(pkBytes),
+		SigningRoot: phase0.Root(msg),
+		Signature:   phase0.BLSSignature(sigBytes),
+	}
+	if _, ok := c.valid.Get(key); ok {
+		c.hits.Add(1)
+		return true, nil
+	}
+	c.misses.Add(1)
+
+	ok, err := bls.VerifySignatureBytes(msg, sigBytes, pkBytes)
+	if ok && err == nil {
+		c.valid.Add(key, struct{}{})
+	}
+	return ok, err
+}
+
+// Stats returns the number of hits, misses, evictions and cached
+// signatures.
+func (c *SignatureCache) Stats() SignatureCacheStats {
+	return SignatureCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Len:       c.valid.Len(),
+	}
+}
+
+// Purge removes all signatures from the cache. The stats are kept.
+func (c *SignatureCache) Purge() {
+	c.valid.Purge()
+}
+
+var signatureCache atomic.Pointer[SignatureCache]
+
+// SetSignatureCache makes VerifySignature and VerifySignatureRoot, and
+// their variants with bls.DecodeStrict, consult c. A nil c turns caching
+// off, which is the default.
+func SetSignatureCache(c *SignatureCache) {
+	signatureCache.Store(c)
+}
+
+// verifySigningRoot verifies a signature of msg through the signature
+// cache, if one is set. Public keys decoded with another mode than
+// bls.DecodeStrict bypass the cache, since they may not have been checked
+// as thoroughly as the cached ones.
+func verifySigningRoot(msg [32]byte, pkBytes, sigBytes []byte, mode bls.DecodeMode) (bool, error) {
+	if c := signatureCache.Load(); c != nil && mode == bls.DecodeStrict {
+		return c.VerifySignatureBytes(msg[:], sigBytes, pkBytes)
+	}
+	return bls.VerifySignatureBytesWithMode(msg[:], sigBytes, pkBytes, mode)
+}
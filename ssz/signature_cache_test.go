@@ -0,0 +1,115 @@
+package ssz
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureCache(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	var evicted []SignatureKey
+	c := NewSignatureCache(2, func(key SignatureKey) { evicted = append(evicted, key) })
+
+	regs := make([]*SignatureKey, 3)
+	for i := range regs {
+		reg := genValidatorRegistration(t, domain)
+		root, err := ComputeSigningRoot(reg.Message, domain)
+		require.NoError(t, err)
+		regs[i] = &SignatureKey{PublicKey: reg.Message.Pubkey, SigningRoot: root, Signature: reg.Signature}
+	}
+	verify := func(key *SignatureKey) (bool, error) {
+		return c.VerifySignatureBytes(key.SigningRoot[:], key.Signature[:], key.PublicKey[:])
+	}
+
+	for range 2 {
+		ok, err := verify(regs[0])
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	require.Equal(t, SignatureCacheStats{Hits: 1, Misses: 1, Len: 1}, c.Stats())
+
+	// Invalid signatures are not cached.
+	invalid := &SignatureKey{PublicKey: regs[0].PublicKey, SigningRoot: regs[1].SigningRoot, Signature: regs[0].Signature}
+	for range 2 {
+		ok, err := verify(invalid)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	_, err := c.VerifySignatureBytes(regs[0].SigningRoot[:], regs[0].Signature[:], []byte{0x01})
+	require.ErrorIs(t, err, bls.ErrInvalidPubkeyLength)
+	require.Equal(t, SignatureCacheStats{Hits: 1, Misses: 3, Len: 1}, c.Stats())
+
+	for _, key := range regs[1:] {
+		ok, err := verify(key)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	require.Equal(t, SignatureCacheStats{Hits: 1, Misses: 5, Evictions: 1, Len: 2}, c.Stats())
+	require.Equal(t, []SignatureKey{*regs[0]}, evicted)
+
+	c.Purge()
+	require.Equal(t, 0, c.Stats().Len)
+}
+
+func TestSetSignatureCache(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	c := NewSignatureCache(16, nil)
+	SetSignatureCache(c)
+	t.Cleanup(func() { SetSignatureCache(nil) })
+
+	reg := genValidatorRegistration(t, domain)
+	pk, sig := reg.Message.Pubkey[:], reg.Signature[:]
+	ok, err := VerifySignature(reg.Message, domain, pk, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+	root, err := reg.Message.HashTreeRoot()
+	require.NoError(t, err)
+	ok, err = VerifySignatureRoot(root, domain, pk, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, SignatureCacheStats{Hits: 1, Misses: 1, Len: 1}, c.Stats())
+
+	// A cached signature is trusted without verifying it again.
+	other := genValidatorRegistration(t, domain)
+	signingRoot, err := ComputeSigningRoot(other.Message, domain)
+	require.NoError(t, err)
+	c.valid.Add(SignatureKey{PublicKey: other.Message.Pubkey, SigningRoot: signingRoot, Signature: reg.Signature}, struct{}{})
+	ok, err = VerifySignature(other.Message, domain, other.Message.Pubkey[:], sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The cache can be bypassed per call, and is for other decode modes.
+	ok, err = VerifySignatureUncached(other.Message, domain, other.Message.Pubkey[:], sig)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = VerifySignatureRootUncached(root, domain, pk, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = VerifySignatureWithMode(other.Message, domain, other.Message.Pubkey[:], sig, bls.DecodeTrusted)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, uint64(2), c.Stats().Hits)
+	require.Equal(t, uint64(1), c.Stats().Misses)
+
+	SetSignatureCache(nil)
+	ok, err = VerifySignature(other.Message, domain, other.Message.Pubkey[:], sig)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func BenchmarkCachedSignatureVerification(b *testing.B) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	reg := genValidatorRegistration(b, domain)
+	SetSignatureCache(NewSignatureCache(1024, nil))
+	defer SetSignatureCache(nil)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		ok, err := VerifySignature(reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+		require.NoError(b, err)
+		require.True(b, ok)
+	}
+}
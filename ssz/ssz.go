@@ -1,10 +1,13 @@
 package ssz
 
 import (
+	"context"
 	"errors"
+	"sort"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/signer"
 )
 
 var (
@@ -53,7 +56,11 @@ func SignMessage(obj ObjWithHashTreeRoot, d phase0.Domain, sk *bls.SecretKey) (p
 		return phase0.BLSSignature{}, err
 	}
 
-	signatureBytes := bls.SignatureToBytes(bls.Sign(sk, root[:]))
+	sig, err := bls.SignE(sk, root[:])
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	signatureBytes := bls.SignatureToBytes(sig)
 
 	var signature phase0.BLSSignature
 	if len(signatureBytes) != 96 {
@@ -68,7 +75,39 @@ func SignMessage(obj ObjWithHashTreeRoot, d phase0.Domain, sk *bls.SecretKey) (p
 	return signature, nil
 }
 
+// SignMessageWithSigner is like SignMessage but signs through s, which may
+// hold its key outside of the process.
+func SignMessageWithSigner(ctx context.Context, obj ObjWithHashTreeRoot, d phase0.Domain, s signer.Signer) (phase0.BLSSignature, error) {
+	if objSigner, ok := s.(signer.ObjectSigner); ok {
+		return objSigner.SignObject(ctx, obj, d)
+	}
+
+	root, err := ComputeSigningRoot(obj, d)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	return s.SignRoot(ctx, root)
+}
+
 func VerifySignature(obj ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes []byte) (bool, error) {
+	return VerifySignatureWithMode(obj, d, pkBytes, sigBytes, bls.DecodeStrict)
+}
+
+// VerifySignatureWithMode is like VerifySignature but decodes the public key
+// with mode.
+func VerifySignatureWithMode(obj ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes []byte, mode bls.DecodeMode) (bool, error) {
+	msg, err := ComputeSigningRoot(obj, d)
+	if err != nil {
+		return false, err
+	}
+
+	return verifySigningRoot(msg, pkBytes, sigBytes, mode)
+}
+
+// VerifySignatureUncached is like VerifySignature but never consults the
+// signature cache set with SetSignatureCache.
+func VerifySignatureUncached(obj ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes []byte) (bool, error) {
 	msg, err := ComputeSigningRoot(obj, d)
 	if err != nil {
 		return false, err
@@ -78,6 +117,24 @@ func VerifySignature(obj ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes
 }
 
 func VerifySignatureRoot(root phase0.Root, d phase0.Domain, pkBytes, sigBytes []byte) (bool, error) {
+	return VerifySignatureRootWithMode(root, d, pkBytes, sigBytes, bls.DecodeStrict)
+}
+
+// VerifySignatureRootWithMode is like VerifySignatureRoot but decodes the
+// public key with mode.
+func VerifySignatureRootWithMode(root phase0.Root, d phase0.Domain, pkBytes, sigBytes []byte, mode bls.DecodeMode) (bool, error) {
+	signingData := phase0.SigningData{ObjectRoot: root, Domain: d}
+	msg, err := signingData.HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+
+	return verifySigningRoot(msg, pkBytes, sigBytes, mode)
+}
+
+// VerifySignatureRootUncached is like VerifySignatureRoot but never consults
+// the signature cache set with SetSignatureCache.
+func VerifySignatureRootUncached(root phase0.Root, d phase0.Domain, pkBytes, sigBytes []byte) (bool, error) {
 	signingData := phase0.SigningData{ObjectRoot: root, Domain: d}
 	msg, err := signingData.HashTreeRoot()
 	if err != nil {
@@ -86,3 +143,73 @@ func VerifySignatureRoot(root phase0.Root, d phase0.Domain, pkBytes, sigBytes []
 
 	return bls.VerifySignatureBytes(msg[:], sigBytes, pkBytes)
 }
+
+// VerifySignaturePrepared is like VerifySignature for a public key that was
+// prepared with bls.NewPreparedPublicKey.
+func VerifySignaturePrepared(obj ObjWithHashTreeRoot, d phase0.Domain, pk *bls.PreparedPublicKey, sigBytes []byte) (bool, error) {
+	msg, err := ComputeSigningRoot(obj, d)
+	if err != nil {
+		return false, err
+	}
+
+	return pk.VerifyBytes(msg[:], sigBytes)
+}
+
+// VerifySignatureRootPrepared is like VerifySignatureRoot for a public key
+// that was prepared with bls.NewPreparedPublicKey.
+func VerifySignatureRootPrepared(root phase0.Root, d phase0.Domain, pk *bls.PreparedPublicKey, sigBytes []byte) (bool, error) {
+	signingData := phase0.SigningData{ObjectRoot: root, Domain: d}
+	msg, err := signingData.HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+
+	return pk.VerifyBytes(msg[:], sigBytes)
+}
+
+// VerifySignatures checks the signatures of objs under the same domain in a
+// single batch and returns the indices of the invalid ones. Entries whose
+// public key or signature cannot be decoded are reported as invalid.
+func VerifySignatures(objs []ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes [][]byte) ([]int, error) {
+	return VerifySignaturesWithMode(objs, d, pkBytes, sigBytes, bls.DecodeStrict)
+}
+
+// VerifySignaturesWithMode is like VerifySignatures but decodes the public
+// keys with mode.
+func VerifySignaturesWithMode(objs []ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes [][]byte, mode bls.DecodeMode) ([]int, error) {
+	if len(objs) != len(pkBytes) || len(objs) != len(sigBytes) {
+		return nil, ErrLength
+	}
+
+	invalid := []int{}
+	sets := make([]*bls.SignatureSet, 0, len(objs))
+	idx := make([]int, 0, len(objs))
+	for i, obj := range objs {
+		msg, err := ComputeSigningRoot(obj, d)
+		if err != nil {
+			return nil, err
+		}
+		pk, err := bls.DecodePublicKey(pkBytes[i], mode)
+		if err != nil {
+			invalid = append(invalid, i)
+			continue
+		}
+		sig, err := bls.SignatureFromBytes(sigBytes[i])
+		if err != nil {
+			invalid = append(invalid, i)
+			continue
+		}
+		sets = append(sets, &bls.SignatureSet{PublicKey: pk, Message: msg[:], Signature: sig})
+		idx = append(idx, i)
+	}
+
+	bad, err := bls.VerifyBatch(sets)
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range bad {
+		invalid = append(invalid, idx[j])
+	}
+	sort.Ints(invalid)
+	return invalid, nil
+}
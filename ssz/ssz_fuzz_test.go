@@ -105,12 +105,15 @@ func FuzzSignMessage(f *testing.F) {
 		if err != nil {
 			return
 		}
-		var sk bls.SecretKey
-		err = tp.Fill(&sk)
+		skBytes, err := tp.GetNBytes(bls.SecretKeyLength)
 		if err != nil {
 			return
 		}
-		_, _ = SignMessage(&forkData, domain, &sk)
+		sk, err := bls.SecretKeyFromBytes(skBytes)
+		if err != nil {
+			return
+		}
+		_, _ = SignMessage(&forkData, domain, sk)
 	})
 }
 
@@ -2,6 +2,7 @@ package ssz
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/flashbots/go-boost-utils/signer"
 	"github.com/flashbots/go-boost-utils/types"
 	"github.com/flashbots/go-boost-utils/utils"
 	"github.com/stretchr/testify/require"
@@ -72,6 +74,56 @@ func TestVerifySignatureRoot(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestSignMessageWithSigner(t *testing.T) {
+	sk, pk, err := bls.GenerateNewKeypair()
+	require.NoError(t, err)
+	s, err := signer.NewLocalSigner(sk)
+	require.NoError(t, err)
+	msg := &builderApiV1.ValidatorRegistration{
+		FeeRecipient: bellatrix.ExecutionAddress{0x42},
+		GasLimit:     15_000_000,
+		Timestamp:    time.Now(),
+		Pubkey:       s.PublicKey(),
+	}
+
+	sig, err := SignMessageWithSigner(context.Background(), msg, DomainBuilder, s)
+	require.NoError(t, err)
+	sig2, err := SignMessage(msg, DomainBuilder, sk)
+	require.NoError(t, err)
+	require.Equal(t, sig2, sig)
+
+	ok, err := VerifySignature(msg, DomainBuilder, bls.PublicKeyToBytes(pk), sig[:])
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = SignMessageWithSigner(ctx, msg, DomainBuilder, s)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestVerifySignatureWithMode(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	reg := genValidatorRegistration(t, domain)
+	root, err := reg.Message.HashTreeRoot()
+	require.NoError(t, err)
+
+	for _, mode := range []bls.DecodeMode{bls.DecodeStrict, bls.DecodeTrusted} {
+		ok, err := VerifySignatureWithMode(reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:], mode)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = VerifySignatureRootWithMode(root, domain, reg.Message.Pubkey[:], reg.Signature[:], mode)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	infinity := make([]byte, bls.PublicKeyLength)
+	infinity[0] = 0xc0
+	_, err = VerifySignatureWithMode(reg.Message, domain, infinity, reg.Signature[:], bls.DecodeTrusted)
+	require.ErrorIs(t, err, bls.ErrPubkeyIsInfinity)
+}
+
 func genValidatorRegistration(t require.TestingT, domain phase0.Domain) *builderApiV1.SignedValidatorRegistration {
 	sk, pk, err := bls.GenerateNewKeypair()
 	require.NoError(t, err)
@@ -107,6 +159,87 @@ func BenchmarkSignatureVerification(b *testing.B) {
 	}
 }
 
+func BenchmarkPreparedSignatureVerification(b *testing.B) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	reg := genValidatorRegistration(b, domain)
+	pk, err := bls.PreparePublicKeyBytes(reg.Message.Pubkey[:])
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		ok, err := VerifySignaturePrepared(reg.Message, domain, pk, reg.Signature[:])
+		require.NoError(b, err)
+		require.True(b, ok)
+	}
+}
+
+func TestVerifySignaturePrepared(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	reg := genValidatorRegistration(t, domain)
+	root, err := reg.Message.HashTreeRoot()
+	require.NoError(t, err)
+	pk, err := bls.PreparePublicKeyBytes(reg.Message.Pubkey[:])
+	require.NoError(t, err)
+
+	ok, err := VerifySignaturePrepared(reg.Message, domain, pk, reg.Signature[:])
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = VerifySignatureRootPrepared(root, domain, pk, reg.Signature[:])
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = VerifySignatureRootPrepared(phase0.Root{}, domain, pk, reg.Signature[:])
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVerifySignatures(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	objs := make([]ObjWithHashTreeRoot, 5)
+	pks := make([][]byte, 5)
+	sigs := make([][]byte, 5)
+	for i := range objs {
+		reg := genValidatorRegistration(t, domain)
+		objs[i] = reg.Message
+		pks[i] = reg.Message.Pubkey[:]
+		sigs[i] = reg.Signature[:]
+	}
+
+	invalid, err := VerifySignatures(objs, domain, pks, sigs)
+	require.NoError(t, err)
+	require.Empty(t, invalid)
+
+	sigs[1] = sigs[0]
+	pks[3] = []byte{0x01}
+	invalid, err = VerifySignatures(objs, domain, pks, sigs)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 3}, invalid)
+
+	_, err = VerifySignatures(objs, domain, pks[:4], sigs)
+	require.ErrorIs(t, err, ErrLength)
+}
+
+func BenchmarkBatchSignatureVerification(b *testing.B) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	objs := make([]ObjWithHashTreeRoot, 128)
+	pks := make([][]byte, 128)
+	sigs := make([][]byte, 128)
+	for i := range objs {
+		reg := genValidatorRegistration(b, domain)
+		objs[i] = reg.Message
+		pks[i] = reg.Message.Pubkey[:]
+		sigs[i] = reg.Signature[:]
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		invalid, err := VerifySignatures(objs, domain, pks, sigs)
+		require.NoError(b, err)
+		require.Empty(b, invalid)
+	}
+}
+
 func TestVerifySignatureManualPk(t *testing.T) {
 	msg := &builderApiV1.ValidatorRegistration{
 		FeeRecipient: bellatrix.ExecutionAddress{0x42},
@@ -268,3 +401,12 @@ func TestKilnSignedBlindedBeaconBlockSignature2(t *testing.T) {
 	htrHex := common.Bytes2Hex(root[:])
 	require.Equal(t, "da469dcc55560d3f8ae26ea6c3910efce3e3b1c4cecc988c3ebafe71e81ad077", htrHex, htrHex)
 }
+
+func TestNetworkDomains(t *testing.T) {
+	for _, n := range types.Networks() {
+		require.Equal(t, phase0.Domain(ComputeDomain(DomainTypeAppBuilder, n.GenesisForkVersion, phase0.Root{})), n.DomainBuilder, n.Name)
+		for _, fork := range n.Forks {
+			require.Equal(t, phase0.Domain(ComputeDomain(DomainTypeBeaconProposer, fork.Version, n.GenesisValidatorsRoot)), fork.DomainBeaconProposer, n.Name)
+		}
+	}
+}
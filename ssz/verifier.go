@@ -0,0 +1,286 @@
+package ssz
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+)
+
+const DefaultVerifierQueueSize = 1024
+
+var ErrVerifierClosed = errors.New("verifier is closed")
+
+type VerifierConfig struct {
+	// Workers is the number of signatures verified in parallel,
+	// runtime.GOMAXPROCS(0) if zero.
+	Workers int
+	// QueueSize is the number of jobs that can wait for a worker before
+	// Submit blocks, DefaultVerifierQueueSize if zero.
+	QueueSize int
+	// MaxBatchSize is the maximum number of queued jobs a worker takes at
+	// once and checks with a single batch verification. Batching is off
+	// if it is zero or one.
+	MaxBatchSize int
+	// Mode is how public keys are decoded, bls.DecodeStrict by default.
+	Mode bls.DecodeMode
+}
+
+// VerifierStats is a snapshot of the usage of a Verifier. Latency is the
+// time from submission to result.
+type VerifierStats struct {
+	QueueDepth  int
+	Submitted   uint64
+	Completed   uint64
+	Canceled    uint64
+	Batches     uint64
+	MeanLatency time.Duration
+	MaxLatency  time.Duration
+}
+
+// Verifier verifies signatures on a bounded pool of workers, so that
+// bursts of requests queue up instead of competing for the CPU. It is safe
+// for concurrent use.
+type Verifier struct {
+	cfg   VerifierConfig
+	queue chan *verifyJob
+	wg    sync.WaitGroup
+
+	// mu guards closed and sending on queue.
+	mu     sync.RWMutex
+	closed bool
+
+	submitted  atomic.Uint64
+	completed  atomic.Uint64
+	canceled   atomic.Uint64
+	batches    atomic.Uint64
+	latencySum atomic.Int64
+	latencyMax atomic.Int64
+}
+
+type verifyJob struct {
+	ctx      context.Context //nolint:containedctx // The job outlives the call to Submit.
+	msg      [32]byte
+	pk, sig  []byte
+	future   *Future
+	enqueued time.Time
+}
+
+// Future is the pending result of a verification.
+type Future struct {
+	done chan struct{}
+	ok   bool
+	err  error
+}
+
+// Done is closed once the result is available.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits for the result of the verification or for ctx to be done,
+// whichever comes first.
+func (f *Future) Wait(ctx context.Context) (bool, error) {
+	select {
+	case <-f.done:
+		return f.ok, f.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// NewVerifier starts the workers of a Verifier, which run until Close.
+func NewVerifier(cfg VerifierConfig) *Verifier {
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.GOMAXPROCS(0)
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultVerifierQueueSize
+	}
+	if cfg.MaxBatchSize < 1 {
+		cfg.MaxBatchSize = 1
+	}
+	v := &Verifier{cfg: cfg, queue: make(chan *verifyJob, cfg.QueueSize)}
+	v.wg.Add(cfg.Workers)
+	for range cfg.Workers {
+		go v.worker()
+	}
+	return v
+}
+
+// Submit queues the verification of the signature of obj like
+// VerifySignature. It blocks while the queue is full until ctx is done. If
+// ctx is done before a worker picks the job up, the result is ctx.Err().
+func (v *Verifier) Submit(ctx context.Context, obj ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes []byte) (*Future, error) {
+	msg, err := ComputeSigningRoot(obj, d)
+	if err != nil {
+		return nil, err
+	}
+	return v.submit(ctx, msg, pkBytes, sigBytes)
+}
+
+// SubmitRoot is like Submit for a signature of an object root, like
+// VerifySignatureRoot.
+func (v *Verifier) SubmitRoot(ctx context.Context, root phase0.Root, d phase0.Domain, pkBytes, sigBytes []byte) (*Future, error) {
+	signingData := phase0.SigningData{ObjectRoot: root, Domain: d}
+	msg, err := signingData.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return v.submit(ctx, msg, pkBytes, sigBytes)
+}
+
+// Verify submits the verification of the signature of obj and waits for
+// the result.
+func (v *Verifier) Verify(ctx context.Context, obj ObjWithHashTreeRoot, d phase0.Domain, pkBytes, sigBytes []byte) (bool, error) {
+	f, err := v.Submit(ctx, obj, d, pkBytes, sigBytes)
+	if err != nil {
+		return false, err
+	}
+	return f.Wait(ctx)
+}
+
+func (v *Verifier) submit(ctx context.Context, msg [32]byte, pkBytes, sigBytes []byte) (*Future, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	job := &verifyJob{
+		ctx:      ctx,
+		msg:      msg,
+		pk:       append([]byte(nil), pkBytes...),
+		sig:      append([]byte(nil), sigBytes...),
+		future:   &Future{done: make(chan struct{})},
+		enqueued: time.Now(),
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.closed {
+		return nil, ErrVerifierClosed
+	}
+	select {
+	case v.queue <- job:
+		v.submitted.Add(1)
+		return job.future, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops accepting jobs, waits for the queued ones to be verified and
+// stops the workers.
+func (v *Verifier) Close() {
+	v.mu.Lock()
+	if !v.closed {
+		v.closed = true
+		close(v.queue)
+	}
+	v.mu.Unlock()
+	v.wg.Wait()
+}
+
+// Stats returns a snapshot of the queue and the verifications so far.
+func (v *Verifier) Stats() VerifierStats {
+	stats := VerifierStats{
+		QueueDepth: len(v.queue),
+		Submitted:  v.submitted.Load(),
+		Completed:  v.completed.Load(),
+		Canceled:   v.canceled.Load(),
+		Batches:    v.batches.Load(),
+		MaxLatency: time.Duration(v.latencyMax.Load()),
+	}
+	if stats.Completed > 0 {
+		stats.MeanLatency = time.Duration(v.latencySum.Load() / int64(stats.Completed))
+	}
+	return stats
+}
+
+func (v *Verifier) worker() {
+	defer v.wg.Done()
+	batch := make([]*verifyJob, 0, v.cfg.MaxBatchSize)
+	for job := range v.queue {
+		batch = append(batch[:0], job)
+	fill:
+		for len(batch) < v.cfg.MaxBatchSize {
+			select {
+			case job, ok := <-v.queue:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, job)
+			default:
+				break fill
+			}
+		}
+		v.verify(batch)
+	}
+}
+
+// verify resolves the futures of batch, checking the signatures together
+// if there are several.
+func (v *Verifier) verify(batch []*verifyJob) {
+	jobs := make([]*verifyJob, 0, len(batch))
+	sets := make([]*bls.SignatureSet, 0, len(batch))
+	for _, job := range batch {
+		if err := job.ctx.Err(); err != nil {
+			v.canceled.Add(1)
+			v.finish(job, false, err)
+			continue
+		}
+		pk, err := bls.DecodePublicKey(job.pk, v.cfg.Mode)
+		if err != nil {
+			v.finish(job, false, err)
+			continue
+		}
+		sig, err := bls.SignatureFromBytes(job.sig)
+		if err != nil {
+			v.finish(job, false, err)
+			continue
+		}
+		jobs = append(jobs, job)
+		sets = append(sets, &bls.SignatureSet{PublicKey: pk, Message: job.msg[:], Signature: sig})
+	}
+
+	if len(sets) > 1 {
+		v.batches.Add(1)
+		invalid, err := bls.VerifyBatch(sets)
+		if err == nil {
+			valid := make([]bool, len(jobs))
+			for i := range valid {
+				valid[i] = true
+			}
+			for _, i := range invalid {
+				valid[i] = false
+			}
+			for i, job := range jobs {
+				v.finish(job, valid[i], nil)
+			}
+			return
+		}
+		// Fall back to verifying the jobs one by one.
+	}
+	for i, job := range jobs {
+		ok, err := bls.VerifySignature(sets[i].Signature, sets[i].PublicKey, sets[i].Message)
+		v.finish(job, ok, err)
+	}
+}
+
+func (v *Verifier) finish(job *verifyJob, ok bool, err error) {
+	job.future.ok, job.future.err = ok, err
+	close(job.future.done)
+
+	latency := int64(time.Since(job.enqueued))
+	v.latencySum.Add(latency)
+	v.completed.Add(1)
+	for {
+		maxLatency := v.latencyMax.Load()
+		if latency <= maxLatency || v.latencyMax.CompareAndSwap(maxLatency, latency) {
+			break
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ssz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	for _, batchSize := range []int{0, 8} {
+		v := NewVerifier(VerifierConfig{Workers: 2, MaxBatchSize: batchSize})
+		ctx := context.Background()
+
+		futures := make([]*Future, 20)
+		for i := range futures {
+			reg := genValidatorRegistration(t, domain)
+			if i%5 == 1 {
+				reg.Signature[5] ^= 0x01
+			}
+			pk := reg.Message.Pubkey[:]
+			if i%5 == 3 {
+				pk = []byte{0x01}
+			}
+			f, err := v.Submit(ctx, reg.Message, domain, pk, reg.Signature[:])
+			require.NoError(t, err)
+			futures[i] = f
+		}
+		for i, f := range futures {
+			ok, err := f.Wait(ctx)
+			switch i % 5 {
+			case 1:
+				// A corrupted signature is invalid or fails to decode.
+				require.False(t, ok)
+			case 3:
+				require.ErrorIs(t, err, bls.ErrInvalidPubkeyLength)
+				require.False(t, ok)
+			default:
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+		}
+
+		reg := genValidatorRegistration(t, domain)
+		ok, err := v.Verify(ctx, reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+		require.NoError(t, err)
+		require.True(t, ok)
+		root, err := reg.Message.HashTreeRoot()
+		require.NoError(t, err)
+		f, err := v.SubmitRoot(ctx, root, domain, reg.Message.Pubkey[:], reg.Signature[:])
+		require.NoError(t, err)
+		ok, err = f.Wait(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		v.Close()
+		stats := v.Stats()
+		require.Equal(t, uint64(22), stats.Submitted)
+		require.Equal(t, uint64(22), stats.Completed)
+		require.Zero(t, stats.QueueDepth)
+		require.Positive(t, stats.MaxLatency)
+		require.LessOrEqual(t, stats.MeanLatency, stats.MaxLatency)
+		if batchSize == 0 {
+			require.Zero(t, stats.Batches)
+		}
+
+		_, err = v.Submit(ctx, reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+		require.ErrorIs(t, err, ErrVerifierClosed)
+		v.Close()
+	}
+}
+
+func TestVerifierBatch(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	// Without workers, jobs stay queued until verify is called directly.
+	v := &Verifier{cfg: VerifierConfig{MaxBatchSize: 8}, queue: make(chan *verifyJob, 8)}
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+
+	var futures []*Future
+	for i := range 6 {
+		reg := genValidatorRegistration(t, domain)
+		if i == 2 {
+			reg.Signature = genValidatorRegistration(t, domain).Signature
+		}
+		jobCtx := ctx
+		if i == 4 {
+			jobCtx = canceled
+		}
+		f, err := v.Submit(jobCtx, reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+		require.NoError(t, err)
+		futures = append(futures, f)
+	}
+	cancel()
+
+	batch := make([]*verifyJob, 0, len(futures))
+	for range futures {
+		batch = append(batch, <-v.queue)
+	}
+	v.verify(batch)
+
+	for i, f := range futures {
+		<-f.Done()
+		ok, err := f.Wait(ctx)
+		switch i {
+		case 2:
+			require.NoError(t, err)
+			require.False(t, ok)
+		case 4:
+			require.ErrorIs(t, err, context.Canceled)
+		default:
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	}
+	stats := v.Stats()
+	require.Equal(t, uint64(1), stats.Batches)
+	require.Equal(t, uint64(1), stats.Canceled)
+	require.Equal(t, uint64(6), stats.Completed)
+}
+
+func TestVerifierBackpressure(t *testing.T) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	reg := genValidatorRegistration(t, domain)
+	v := &Verifier{cfg: VerifierConfig{MaxBatchSize: 1}, queue: make(chan *verifyJob, 1)}
+
+	f, err := v.Submit(context.Background(), reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+	require.NoError(t, err)
+	require.Equal(t, 1, v.Stats().QueueDepth)
+
+	// The queue is full, so Submit blocks until the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = v.Submit(ctx, reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Waiting for a future gives up with its context.
+	_, err = f.Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = v.Submit(ctx, reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func BenchmarkVerifier(b *testing.B) {
+	domain := ComputeDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, phase0.Version{}, phase0.Root{})
+	reg := genValidatorRegistration(b, domain)
+	v := NewVerifier(VerifierConfig{MaxBatchSize: 16})
+	defer v.Close()
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ok, err := v.Verify(ctx, reg.Message, domain, reg.Message.Pubkey[:], reg.Signature[:])
+			require.NoError(b, err)
+			require.True(b, ok)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/goccy/go-yaml"
+)
+
+// FarFutureEpoch is the epoch of forks that are not scheduled.
+const FarFutureEpoch = phase0.Epoch(math.MaxUint64)
+
+var ErrInvalidConfig = errors.New("invalid network config")
+
+// BlobParameters is an entry of a blob schedule.
+type BlobParameters struct {
+	Epoch            phase0.Epoch `yaml:"EPOCH"`               //nolint:tagliatelle // consensus-specs config format
+	MaxBlobsPerBlock uint64       `yaml:"MAX_BLOBS_PER_BLOCK"` //nolint:tagliatelle // consensus-specs config format
+}
+
+// NetworkConfig is the part of a consensus-specs config.yaml that
+// describes a network's forks, as shipped with the networks and generated
+// for devnets.
+type NetworkConfig struct {
+	Name           string
+	SecondsPerSlot uint64
+	// Forks are the scheduled forks in order, starting with phase 0. Forks
+	// at FarFutureEpoch and forks unknown to spec.DataVersion are left out.
+	Forks []Fork
+	// BlobSchedule is ordered by epoch.
+	BlobSchedule []BlobParameters
+}
+
+// LoadNetworkConfig reads a config.yaml with ParseNetworkConfig.
+func LoadNetworkConfig(path string) (*NetworkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetworkConfig(data)
+}
+
+// ParseNetworkConfig parses CONFIG_NAME, SECONDS_PER_SLOT,
+// GENESIS_FORK_VERSION, the <FORK>_FORK_VERSION and <FORK>_FORK_EPOCH of
+// every later fork, and BLOB_SCHEDULE from a config.yaml. Other keys are
+// ignored.
+func ParseNetworkConfig(data []byte) (*NetworkConfig, error) {
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	var blobs struct {
+		BlobSchedule []BlobParameters `yaml:"BLOB_SCHEDULE"` //nolint:tagliatelle // consensus-specs config format
+	}
+	if err := yaml.Unmarshal(data, &blobs); err != nil {
+		return nil, fmt.Errorf("%w: BLOB_SCHEDULE: %w", ErrInvalidConfig, err)
+	}
+
+	c := &NetworkConfig{BlobSchedule: blobs.BlobSchedule}
+	if name, ok := values["CONFIG_NAME"].(string); ok {
+		c.Name = name
+	}
+	secondsPerSlot, err := configUint(values, "SECONDS_PER_SLOT")
+	if err != nil {
+		return nil, err
+	}
+	c.SecondsPerSlot = secondsPerSlot
+
+	genesisForkVersion, err := configVersion(values, "GENESIS_FORK_VERSION")
+	if err != nil {
+		return nil, err
+	}
+	c.Forks = []Fork{{Name: spec.DataVersionPhase0, Version: genesisForkVersion}}
+	for v := spec.DataVersionAltair; v.String() != "unknown"; v++ {
+		prefix := strings.ToUpper(v.String())
+		if _, ok := values[prefix+"_FORK_EPOCH"]; !ok {
+			continue
+		}
+		epoch, err := configUint(values, prefix+"_FORK_EPOCH")
+		if err != nil {
+			return nil, err
+		}
+		if phase0.Epoch(epoch) == FarFutureEpoch {
+			continue
+		}
+		version, err := configVersion(values, prefix+"_FORK_VERSION")
+		if err != nil {
+			return nil, err
+		}
+		c.Forks = append(c.Forks, Fork{Name: v, Version: version, Epoch: phase0.Epoch(epoch)})
+	}
+
+	slices.SortStableFunc(c.BlobSchedule, func(a, b BlobParameters) int {
+		return cmp.Compare(a.Epoch, b.Epoch)
+	})
+	return c, nil
+}
+
+// Network returns the network of the config with the given genesis, which
+// config.yaml does not hold.
+func (c *NetworkConfig) Network(genesisTime time.Time, genesisValidatorsRoot phase0.Root) (*Network, error) {
+	n, err := NewNetwork(c.Name, genesisTime, genesisValidatorsRoot, c.SecondsPerSlot, c.Forks)
+	if err != nil {
+		return nil, err
+	}
+	n.BlobSchedule = slices.Clone(c.BlobSchedule)
+	return n, nil
+}
+
+func configUint(values map[string]any, key string) (uint64, error) {
+	switch v := values[key].(type) {
+	case uint64:
+		return v, nil
+	case int64:
+		if v >= 0 {
+			return uint64(v), nil
+		}
+	case nil:
+		return 0, fmt.Errorf("%w: missing %s", ErrInvalidConfig, key)
+	}
+	return 0, fmt.Errorf("%w: %s is not an unsigned integer", ErrInvalidConfig, key)
+}
+
+// configVersion reads a fork version, which YAML parses as an integer
+// unless it is quoted.
+func configVersion(values map[string]any, key string) (phase0.Version, error) {
+	var version phase0.Version
+	switch v := values[key].(type) {
+	case uint64:
+		if v <= math.MaxUint32 {
+			binary.BigEndian.PutUint32(version[:], uint32(v))
+			return version, nil
+		}
+	case string:
+		b, err := hexutil.Decode(v)
+		if err == nil && len(b) == len(version) {
+			return phase0.Version(b), nil
+		}
+	case nil:
+		return version, fmt.Errorf("%w: missing %s", ErrInvalidConfig, key)
+	}
+	return version, fmt.Errorf("%w: %s is not a fork version", ErrInvalidConfig, key)
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadNetworkConfig(t *testing.T) {
+	c, err := LoadNetworkConfig("../testdata/config/mainnet.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", c.Name)
+	require.Equal(t, []BlobParameters{{Epoch: 412672, MaxBlobsPerBlock: 15}, {Epoch: 419072, MaxBlobsPerBlock: 21}}, c.BlobSchedule)
+
+	n, err := c.Network(Mainnet.GenesisTime, Mainnet.GenesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, Mainnet.Forks, n.Forks)
+	require.Equal(t, Mainnet.DomainBuilder, n.DomainBuilder)
+	require.Equal(t, Mainnet.SecondsPerSlot, n.SecondsPerSlot)
+
+	_, ok := n.MaxBlobsPerBlock(412671)
+	require.False(t, ok)
+	maxBlobs, ok := n.MaxBlobsPerBlock(412672)
+	require.True(t, ok)
+	require.Equal(t, uint64(15), maxBlobs)
+	maxBlobs, _ = n.MaxBlobsPerBlock(FarFutureEpoch)
+	require.Equal(t, uint64(21), maxBlobs)
+}
+
+func TestLoadNetworkConfigDevnet(t *testing.T) {
+	c, err := LoadNetworkConfig("../testdata/config/devnet.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "testnet", c.Name)
+	require.Equal(t, uint64(6), c.SecondsPerSlot)
+	require.Len(t, c.Forks, 7)
+
+	n, err := c.Network(time.Unix(1760000060, 0), phase0.Root{0x42})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Version{0x10, 0x00, 0x00, 0x38}, n.GenesisForkVersion)
+	require.Equal(t, spec.DataVersionElectra, n.ForkAtEpoch(1).Name)
+	fulu := n.ForkAtEpoch(2)
+	require.Equal(t, spec.DataVersionFulu, fulu.Name)
+	require.Equal(t, phase0.Version{0x70, 0x00, 0x00, 0x38}, fulu.Version)
+	require.Equal(t, computeDomain(domainTypeBeaconProposer, fulu.Version, phase0.Root{0x42}), fulu.DomainBeaconProposer)
+	require.Equal(t, computeDomain(domainTypeAppBuilder, n.GenesisForkVersion, phase0.Root{}), n.DomainBuilder)
+	maxBlobs, _ := n.MaxBlobsPerBlock(3)
+	require.Equal(t, uint64(12), maxBlobs)
+}
+
+func TestParseNetworkConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		Name   string
+		Config string
+	}{
+		{Name: "invalid yaml", Config: "GENESIS_FORK_VERSION: [\n"},
+		{Name: "missing genesis fork version", Config: "SECONDS_PER_SLOT: 12\n"},
+		{Name: "missing seconds per slot", Config: "GENESIS_FORK_VERSION: 0x00000000\n"},
+		{Name: "negative seconds per slot", Config: "GENESIS_FORK_VERSION: 0x00000000\nSECONDS_PER_SLOT: -1\n"},
+		{Name: "long fork version", Config: "GENESIS_FORK_VERSION: '0x0000000000'\nSECONDS_PER_SLOT: 12\n"},
+		{Name: "large fork version", Config: "GENESIS_FORK_VERSION: 0x0100000000\nSECONDS_PER_SLOT: 12\n"},
+		{Name: "missing fork version", Config: "GENESIS_FORK_VERSION: 0x00000000\nSECONDS_PER_SLOT: 12\nALTAIR_FORK_EPOCH: 10\n"},
+		{Name: "invalid blob schedule", Config: "GENESIS_FORK_VERSION: 0x00000000\nSECONDS_PER_SLOT: 12\nBLOB_SCHEDULE: 3\n"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ParseNetworkConfig([]byte(tc.Config))
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+
+	_, err := LoadNetworkConfig("../testdata/config/missing.yaml")
+	require.Error(t, err)
+
+	// Forks out of order are caught when the network is built.
+	c, err := ParseNetworkConfig([]byte("GENESIS_FORK_VERSION: 0x00000000\nSECONDS_PER_SLOT: 12\nALTAIR_FORK_VERSION: 0x01000000\nALTAIR_FORK_EPOCH: 10\nBELLATRIX_FORK_VERSION: 0x02000000\nBELLATRIX_FORK_EPOCH: 5\n"))
+	require.NoError(t, err)
+	_, err = c.Network(time.Unix(0, 0), phase0.Root{})
+	require.ErrorIs(t, err, ErrInvalidForkSchedule)
+}
@@ -0,0 +1,221 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrInvalidForkSchedule = errors.New("invalid fork schedule")
+
+// The domain types of ssz, which cannot be imported here.
+var (
+	domainTypeBeaconProposer = phase0.DomainType{0x00, 0x00, 0x00, 0x00}
+	domainTypeAppBuilder     = phase0.DomainType{0x00, 0x00, 0x00, 0x01}
+)
+
+// Fork is a fork of a network's schedule.
+type Fork struct {
+	Name    spec.DataVersion
+	Version phase0.Version
+	Epoch   phase0.Epoch
+	// DomainBeaconProposer is the domain of blocks proposed in the fork.
+	DomainBeaconProposer phase0.Domain
+}
+
+// Network holds the parameters of a beacon chain network. Networks
+// returned by this package are shared and must not be modified.
+type Network struct {
+	Name                  string
+	GenesisTime           time.Time
+	GenesisValidatorsRoot phase0.Root
+	GenesisForkVersion    phase0.Version
+	SecondsPerSlot        uint64
+	// Forks are the scheduled forks in order, starting with phase 0.
+	Forks []Fork
+	// DomainBuilder is the domain of builder API messages.
+	DomainBuilder phase0.Domain
+	// BlobSchedule is the blob schedule of networks loaded from a
+	// NetworkConfig, ordered by epoch.
+	BlobSchedule []BlobParameters
+}
+
+// NewNetwork returns a network with the fork schedule forks, which must
+// start with phase 0 at epoch 0 and list each later fork once, in order.
+// The domains are computed from the fork versions.
+func NewNetwork(name string, genesisTime time.Time, genesisValidatorsRoot phase0.Root, secondsPerSlot uint64, forks []Fork) (*Network, error) {
+	if len(forks) == 0 || forks[0].Name != spec.DataVersionPhase0 || forks[0].Epoch != 0 {
+		return nil, ErrInvalidForkSchedule
+	}
+	n := &Network{
+		Name:                  name,
+		GenesisTime:           genesisTime,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		GenesisForkVersion:    forks[0].Version,
+		SecondsPerSlot:        secondsPerSlot,
+		Forks:                 make([]Fork, len(forks)),
+		DomainBuilder:         computeDomain(domainTypeAppBuilder, forks[0].Version, phase0.Root{}),
+	}
+	for i, fork := range forks {
+		if i > 0 && (fork.Name <= forks[i-1].Name || fork.Epoch < forks[i-1].Epoch) {
+			return nil, ErrInvalidForkSchedule
+		}
+		fork.DomainBeaconProposer = computeDomain(domainTypeBeaconProposer, fork.Version, genesisValidatorsRoot)
+		n.Forks[i] = fork
+	}
+	return n, nil
+}
+
+// ForkAtEpoch returns the fork that is active at epoch.
+func (n *Network) ForkAtEpoch(epoch phase0.Epoch) Fork {
+	fork := n.Forks[0]
+	for _, f := range n.Forks[1:] {
+		if f.Epoch > epoch {
+			break
+		}
+		fork = f
+	}
+	return fork
+}
+
+// Fork returns the fork of the given name, if it is scheduled.
+func (n *Network) Fork(name spec.DataVersion) (Fork, bool) {
+	for _, fork := range n.Forks {
+		if fork.Name == name {
+			return fork, true
+		}
+	}
+	return Fork{}, false
+}
+
+// MaxBlobsPerBlock returns the maximum number of blobs per block at epoch
+// according to the blob schedule, if an entry applies.
+func (n *Network) MaxBlobsPerBlock(epoch phase0.Epoch) (uint64, bool) {
+	var maxBlobs uint64
+	ok := false
+	for _, p := range n.BlobSchedule {
+		if p.Epoch > epoch {
+			break
+		}
+		maxBlobs, ok = p.MaxBlobsPerBlock, true
+	}
+	return maxBlobs, ok
+}
+
+// SlotStartTime returns the time slot starts at.
+func (n *Network) SlotStartTime(slot phase0.Slot) time.Time {
+	return n.GenesisTime.Add(time.Duration(uint64(slot)*n.SecondsPerSlot) * time.Second)
+}
+
+func computeDomain(dt phase0.DomainType, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) phase0.Domain {
+	forkDataRoot, _ := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+
+	var domain phase0.Domain
+	copy(domain[0:4], dt[:])
+	copy(domain[4:], forkDataRoot[0:28])
+	return domain
+}
+
+var (
+	Mainnet = mustNetwork("mainnet", 1606824023, "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95", 12, []Fork{
+		{Name: spec.DataVersionPhase0, Version: phase0.Version{0x00, 0x00, 0x00, 0x00}, Epoch: 0},
+		{Name: spec.DataVersionAltair, Version: phase0.Version{0x01, 0x00, 0x00, 0x00}, Epoch: 74240},
+		{Name: spec.DataVersionBellatrix, Version: phase0.Version{0x02, 0x00, 0x00, 0x00}, Epoch: 144896},
+		{Name: spec.DataVersionCapella, Version: phase0.Version{0x03, 0x00, 0x00, 0x00}, Epoch: 194048},
+		{Name: spec.DataVersionDeneb, Version: phase0.Version{0x04, 0x00, 0x00, 0x00}, Epoch: 269568},
+		{Name: spec.DataVersionElectra, Version: phase0.Version{0x05, 0x00, 0x00, 0x00}, Epoch: 364032},
+		{Name: spec.DataVersionFulu, Version: phase0.Version{0x06, 0x00, 0x00, 0x00}, Epoch: 411392},
+	})
+	Sepolia = mustNetwork("sepolia", 1655733600, "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078", 12, []Fork{
+		{Name: spec.DataVersionPhase0, Version: phase0.Version{0x90, 0x00, 0x00, 0x69}, Epoch: 0},
+		{Name: spec.DataVersionAltair, Version: phase0.Version{0x90, 0x00, 0x00, 0x70}, Epoch: 50},
+		{Name: spec.DataVersionBellatrix, Version: phase0.Version{0x90, 0x00, 0x00, 0x71}, Epoch: 100},
+		{Name: spec.DataVersionCapella, Version: phase0.Version{0x90, 0x00, 0x00, 0x72}, Epoch: 56832},
+		{Name: spec.DataVersionDeneb, Version: phase0.Version{0x90, 0x00, 0x00, 0x73}, Epoch: 132608},
+		{Name: spec.DataVersionElectra, Version: phase0.Version{0x90, 0x00, 0x00, 0x74}, Epoch: 222464},
+		{Name: spec.DataVersionFulu, Version: phase0.Version{0x90, 0x00, 0x00, 0x75}, Epoch: 272640},
+	})
+	Holesky = mustNetwork("holesky", 1695902400, "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1", 12, []Fork{
+		{Name: spec.DataVersionPhase0, Version: phase0.Version{0x01, 0x01, 0x70, 0x00}, Epoch: 0},
+		{Name: spec.DataVersionAltair, Version: phase0.Version{0x02, 0x01, 0x70, 0x00}, Epoch: 0},
+		{Name: spec.DataVersionBellatrix, Version: phase0.Version{0x03, 0x01, 0x70, 0x00}, Epoch: 0},
+		{Name: spec.DataVersionCapella, Version: phase0.Version{0x04, 0x01, 0x70, 0x00}, Epoch: 256},
+		{Name: spec.DataVersionDeneb, Version: phase0.Version{0x05, 0x01, 0x70, 0x00}, Epoch: 29696},
+		{Name: spec.DataVersionElectra, Version: phase0.Version{0x06, 0x01, 0x70, 0x00}, Epoch: 115968},
+		{Name: spec.DataVersionFulu, Version: phase0.Version{0x07, 0x01, 0x70, 0x00}, Epoch: 165120},
+	})
+	Hoodi = mustNetwork("hoodi", 1742213400, "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f", 12, []Fork{
+		{Name: spec.DataVersionPhase0, Version: phase0.Version{0x10, 0x00, 0x09, 0x10}, Epoch: 0},
+		{Name: spec.DataVersionAltair, Version: phase0.Version{0x20, 0x00, 0x09, 0x10}, Epoch: 0},
+		{Name: spec.DataVersionBellatrix, Version: phase0.Version{0x30, 0x00, 0x09, 0x10}, Epoch: 0},
+		{Name: spec.DataVersionCapella, Version: phase0.Version{0x40, 0x00, 0x09, 0x10}, Epoch: 0},
+		{Name: spec.DataVersionDeneb, Version: phase0.Version{0x50, 0x00, 0x09, 0x10}, Epoch: 0},
+		{Name: spec.DataVersionElectra, Version: phase0.Version{0x60, 0x00, 0x09, 0x10}, Epoch: 2048},
+		{Name: spec.DataVersionFulu, Version: phase0.Version{0x70, 0x00, 0x09, 0x10}, Epoch: 50688},
+	})
+	Gnosis = mustNetwork("gnosis", 1638993340, "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47", 5, []Fork{
+		{Name: spec.DataVersionPhase0, Version: phase0.Version{0x00, 0x00, 0x00, 0x64}, Epoch: 0},
+		{Name: spec.DataVersionAltair, Version: phase0.Version{0x01, 0x00, 0x00, 0x64}, Epoch: 512},
+		{Name: spec.DataVersionBellatrix, Version: phase0.Version{0x02, 0x00, 0x00, 0x64}, Epoch: 385536},
+		{Name: spec.DataVersionCapella, Version: phase0.Version{0x03, 0x00, 0x00, 0x64}, Epoch: 648704},
+		{Name: spec.DataVersionDeneb, Version: phase0.Version{0x04, 0x00, 0x00, 0x64}, Epoch: 889856},
+		{Name: spec.DataVersionElectra, Version: phase0.Version{0x05, 0x00, 0x00, 0x64}, Epoch: 1337856},
+	})
+
+	networks = []*Network{Mainnet, Sepolia, Holesky, Hoodi, Gnosis}
+)
+
+func mustNetwork(name string, genesisTime int64, genesisValidatorsRoot string, secondsPerSlot uint64, forks []Fork) *Network {
+	root := phase0.Root(hexutil.MustDecode(genesisValidatorsRoot))
+	n, err := NewNetwork(name, time.Unix(genesisTime, 0).UTC(), root, secondsPerSlot, forks)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// Networks returns the built-in networks.
+func Networks() []*Network {
+	return append([]*Network(nil), networks...)
+}
+
+// NetworkByName returns the built-in network called name, ignoring case.
+func NetworkByName(name string) (*Network, bool) {
+	for _, n := range networks {
+		if strings.EqualFold(n.Name, name) {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// NetworkByGenesisValidatorsRoot returns the built-in network with the
+// genesis validators root root.
+func NetworkByGenesisValidatorsRoot(root phase0.Root) (*Network, bool) {
+	for _, n := range networks {
+		if n.GenesisValidatorsRoot == root {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// NetworkByForkVersion returns the built-in network with a fork of version
+// v, along with the fork.
+func NetworkByForkVersion(v phase0.Version) (*Network, Fork, bool) {
+	for _, n := range networks {
+		for _, fork := range n.Forks {
+			if fork.Version == v {
+				return n, fork, true
+			}
+		}
+	}
+	return nil, Fork{}, false
+}
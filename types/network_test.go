@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworks(t *testing.T) {
+	require.Equal(t, "0x00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9", hexutil.Encode(Mainnet.DomainBuilder[:]))
+	require.Equal(t, "0x00000001d3010778cd08ee514b08fe67b6c503b510987a4ce43f42306d97c67c", hexutil.Encode(Sepolia.DomainBuilder[:]))
+
+	// The networks agree with the hex constants.
+	for _, tc := range []struct {
+		Network               *Network
+		GenesisValidatorsRoot string
+		GenesisForkVersion    string
+		BellatrixForkVersion  string
+	}{
+		{Mainnet, GenesisValidatorsRootMainnet, GenesisForkVersionMainnet, BellatrixForkVersionMainnet},
+		{Sepolia, GenesisValidatorsRootSepolia, GenesisForkVersionSepolia, BellatrixForkVersionSepolia},
+	} {
+		require.Equal(t, tc.GenesisValidatorsRoot, hexutil.Encode(tc.Network.GenesisValidatorsRoot[:]))
+		require.Equal(t, tc.GenesisForkVersion, hexutil.Encode(tc.Network.GenesisForkVersion[:]))
+		fork, ok := tc.Network.Fork(spec.DataVersionBellatrix)
+		require.True(t, ok)
+		require.Equal(t, tc.BellatrixForkVersion, hexutil.Encode(fork.Version[:]))
+	}
+
+	for _, n := range Networks() {
+		got, ok := NetworkByName(n.Name)
+		require.True(t, ok)
+		require.Same(t, n, got)
+		got, ok = NetworkByGenesisValidatorsRoot(n.GenesisValidatorsRoot)
+		require.True(t, ok)
+		require.Same(t, n, got)
+		for _, fork := range n.Forks {
+			got, gotFork, ok := NetworkByForkVersion(fork.Version)
+			require.True(t, ok)
+			require.Same(t, n, got)
+			require.Equal(t, fork, gotFork)
+		}
+	}
+
+	n, ok := NetworkByName("Hoodi")
+	require.True(t, ok)
+	require.Same(t, Hoodi, n)
+	_, ok = NetworkByName("goerli")
+	require.False(t, ok)
+	_, ok = NetworkByGenesisValidatorsRoot(phase0.Root{})
+	require.False(t, ok)
+	_, _, ok = NetworkByForkVersion(phase0.Version{0x00, 0x00, 0x10, 0x20})
+	require.False(t, ok)
+	_, ok = Gnosis.Fork(spec.DataVersionFulu)
+	require.False(t, ok)
+}
+
+func TestForkAtEpoch(t *testing.T) {
+	require.Equal(t, spec.DataVersionPhase0, Mainnet.ForkAtEpoch(0).Name)
+	require.Equal(t, spec.DataVersionAltair, Mainnet.ForkAtEpoch(144895).Name)
+	require.Equal(t, spec.DataVersionBellatrix, Mainnet.ForkAtEpoch(144896).Name)
+	require.Equal(t, spec.DataVersionFulu, Mainnet.ForkAtEpoch(1<<40).Name)
+	// Forks at the same epoch are superseded by the last of them.
+	require.Equal(t, spec.DataVersionDeneb, Hoodi.ForkAtEpoch(0).Name)
+}
+
+func TestSlotStartTime(t *testing.T) {
+	require.Equal(t, Mainnet.GenesisTime, Mainnet.SlotStartTime(0))
+	// The first post-merge block of mainnet.
+	require.Equal(t, time.Date(2022, 9, 15, 6, 42, 59, 0, time.UTC), Mainnet.SlotStartTime(4700013))
+	require.Equal(t, Gnosis.GenesisTime.Add(5*time.Second), Gnosis.SlotStartTime(1))
+}
+
+func TestNewNetwork(t *testing.T) {
+	phase0Fork := Fork{Name: spec.DataVersionPhase0, Version: phase0.Version{0x10}}
+	altair := Fork{Name: spec.DataVersionAltair, Version: phase0.Version{0x20}, Epoch: 10}
+	n, err := NewNetwork("devnet", time.Unix(0, 0), phase0.Root{0x01}, 6, []Fork{phase0Fork, altair})
+	require.NoError(t, err)
+	require.Equal(t, phase0Fork.Version, n.GenesisForkVersion)
+	require.Equal(t, computeDomain(domainTypeBeaconProposer, altair.Version, phase0.Root{0x01}), n.ForkAtEpoch(10).DomainBeaconProposer)
+
+	for _, forks := range [][]Fork{
+		nil,
+		{altair},
+		{{Name: spec.DataVersionPhase0, Epoch: 1}},
+		{phase0Fork, altair, altair},
+		{phase0Fork, {Name: spec.DataVersionBellatrix, Epoch: 5}, {Name: spec.DataVersionCapella, Epoch: 4}},
+	} {
+		_, err := NewNetwork("devnet", time.Unix(0, 0), phase0.Root{}, 12, forks)
+		require.ErrorIs(t, err, ErrInvalidForkSchedule)
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	utilbellatrix "github.com/attestantio/go-eth2-client/util/bellatrix"
 	utilcapella "github.com/attestantio/go-eth2-client/util/capella"
-	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -34,11 +33,11 @@ var (
 )
 
 func BlsPublicKeyToPublicKey(blsPubKey *bls.PublicKey) (ret phase0.BLSPubKey, err error) {
-	return HexToPubkey(hexutil.Encode(bls.PublicKeyToBytes(blsPubKey)))
+	return HexToPubkeyWithMode(hexutil.Encode(bls.PublicKeyToBytes(blsPubKey)), bls.DecodeTrusted)
 }
 
 func BlsSignatureToSignature(blsSignature *bls.Signature) (ret phase0.BLSSignature, err error) {
-	return HexToSignature(hexutil.Encode(bls.SignatureToBytes(blsSignature)))
+	return HexToSignatureWithMode(hexutil.Encode(bls.SignatureToBytes(blsSignature)), bls.DecodeTrusted)
 }
 
 // HexToHash takes a hex string and returns a Hash
@@ -61,8 +60,15 @@ func HexToAddress(s string) (ret bellatrix.ExecutionAddress, err error) {
 	return
 }
 
-// HexToPubkey takes a hex string and returns a PublicKey
+// HexToPubkey takes a hex string and returns a PublicKey, validated with
+// bls.DecodeStrict
 func HexToPubkey(s string) (ret phase0.BLSPubKey, err error) {
+	return HexToPubkeyWithMode(s, bls.DecodeStrict)
+}
+
+// HexToPubkeyWithMode takes a hex string and returns a PublicKey, validated
+// with the given decode mode
+func HexToPubkeyWithMode(s string, mode bls.DecodeMode) (ret phase0.BLSPubKey, err error) {
 	bytes, err := hexutil.Decode(s)
 	if err != nil {
 		return phase0.BLSPubKey{}, err
@@ -70,16 +76,25 @@ func HexToPubkey(s string) (ret phase0.BLSPubKey, err error) {
 	if len(bytes) != len(ret) {
 		return phase0.BLSPubKey{}, ErrLength
 	}
-	_, err = new(bls12381.G1Affine).SetBytes(bytes)
+	_, err = bls.DecodePublicKey(bytes, mode)
 	if err != nil {
-		return phase0.BLSPubKey{}, ErrInvalidPubkey
+		return phase0.BLSPubKey{}, fmt.Errorf("%w: %w", ErrInvalidPubkey, err)
 	}
 	copy(ret[:], bytes)
 	return
 }
 
-// HexToSignature takes a hex string and returns a Signature
+// HexToSignature takes a hex string and returns a Signature, validated with
+// bls.DecodeStrict. The point at infinity, which earlier versions accepted,
+// is rejected with ErrInvalidSignature
 func HexToSignature(s string) (ret phase0.BLSSignature, err error) {
+	return HexToSignatureWithMode(s, bls.DecodeStrict)
+}
+
+// HexToSignatureWithMode takes a hex string and returns a Signature,
+// validated with the given decode mode. The point at infinity is rejected
+// in every mode, bls.DecodeTrusted included
+func HexToSignatureWithMode(s string, mode bls.DecodeMode) (ret phase0.BLSSignature, err error) {
 	bytes, err := hexutil.Decode(s)
 	if err != nil {
 		return phase0.BLSSignature{}, err
@@ -87,9 +102,9 @@ func HexToSignature(s string) (ret phase0.BLSSignature, err error) {
 	if len(bytes) != len(ret) {
 		return phase0.BLSSignature{}, ErrLength
 	}
-	_, err = new(bls12381.G2Affine).SetBytes(bytes)
+	_, err = bls.DecodeSignature(bytes, mode)
 	if err != nil {
-		return phase0.BLSSignature{}, ErrInvalidSignature
+		return phase0.BLSSignature{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
 	}
 	copy(ret[:], bytes)
 	return
@@ -10,6 +10,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/flashbots/go-boost-utils/bls"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,7 +50,17 @@ func TestHexToPubkey(t *testing.T) {
 		{
 			name:        "Invalid pubkey (not on the curve)",
 			pubkey:      "0xed7f862045422bd51ba732730ce993c94d2545e5db1112102026343904fcdf6f5cf37926a3688444703772ed80fa223f",
-			expectedErr: "invalid pubkey",
+			expectedErr: "invalid pubkey: cannot decode point: invalid point encoding",
+		},
+		{
+			name:        "Invalid pubkey (point at infinity)",
+			pubkey:      "0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			expectedErr: "invalid pubkey: invalid public key is the point at infinity",
+		},
+		{
+			name:        "Invalid pubkey (not in subgroup)",
+			pubkey:      "0x800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004",
+			expectedErr: "invalid pubkey: invalid public key is not in the G1 subgroup",
 		},
 		{
 			name:        "Invalid pubkey (no 0x prefix)",
@@ -76,6 +87,18 @@ func TestHexToPubkey(t *testing.T) {
 	}
 }
 
+func TestHexToPubkeyWithMode(t *testing.T) {
+	notInSubgroup := "0x800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004"
+
+	_, err := HexToPubkeyWithMode(notInSubgroup, bls.DecodeStrict)
+	require.ErrorIs(t, err, ErrInvalidPubkey)
+	require.ErrorIs(t, err, bls.ErrPubkeyNotInSubgroup)
+
+	result, err := HexToPubkeyWithMode(notInSubgroup, bls.DecodeTrusted)
+	require.NoError(t, err)
+	require.Equal(t, notInSubgroup, result.String())
+}
+
 func TestHexToSignature(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -94,7 +117,12 @@ func TestHexToSignature(t *testing.T) {
 		{
 			name:        "Invalid signature (not on the curve)",
 			signature:   "0xb8f03e639b91fa8e9892f66c798f07f6e7b3453234f643b2c06a35c5149cf6d85e4e1572c33549fe749292445fbff9e0739c78159324c35dc1a90e5745ca70c8caf1b63fb6678d81bd2d5cb6baeb1462df7a93877d0e22a31dd6438334536d9a",
-			expectedErr: "invalid signature",
+			expectedErr: "invalid signature: cannot decode point: invalid fp.Element encoding",
+		},
+		{
+			name:        "Invalid signature (point at infinity)",
+			signature:   "0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			expectedErr: "invalid signature: invalid signature is the point at infinity",
 		},
 		{
 			name:        "Invalid signature (no 0x prefix)",